@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	_ "time/tzdata"
 
 	_ "github.com/wazwki/skillsrock/docs"
 	"github.com/wazwki/skillsrock/internal/app"
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS trigger_claim_ownerless_tasks ON users;
+DROP FUNCTION IF EXISTS claim_ownerless_tasks();
+
+DROP INDEX IF EXISTS idx_tasks_user_id;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS user_id;
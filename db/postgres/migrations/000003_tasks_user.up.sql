@@ -0,0 +1,22 @@
+ALTER TABLE tasks ADD COLUMN user_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_tasks_user_id ON tasks(user_id);
+
+-- Tasks from before they had owners go to the first registered user, the
+-- only account of the former single-user setup. Without any user yet they
+-- are claimed by the first one to register, so that no task is left
+-- ownerless and out of reach.
+UPDATE tasks SET user_id = (SELECT MIN(id) FROM users) WHERE user_id IS NULL;
+
+CREATE FUNCTION claim_ownerless_tasks()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE tasks SET user_id = NEW.id WHERE user_id IS NULL;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_claim_ownerless_tasks
+AFTER INSERT ON users
+FOR EACH ROW
+EXECUTE FUNCTION claim_ownerless_tasks();
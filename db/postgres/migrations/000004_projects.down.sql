@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_tasks_project_id;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS project_id;
+
+DROP TABLE IF EXISTS project_members;
+
+DROP TABLE IF EXISTS projects;
+
+DROP TYPE IF EXISTS project_role;
@@ -0,0 +1,7 @@
+ALTER TABLE users DROP COLUMN IF EXISTS password_reset_required;
+
+ALTER TABLE users DROP COLUMN IF EXISTS disabled_at;
+
+ALTER TABLE users DROP COLUMN IF EXISTS role;
+
+DROP TYPE IF EXISTS user_role;
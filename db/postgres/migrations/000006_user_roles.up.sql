@@ -0,0 +1,6 @@
+CREATE TYPE user_role AS ENUM ('admin', 'member', 'readonly');
+
+ALTER TABLE users
+    ADD COLUMN role user_role NOT NULL DEFAULT 'member',
+    ADD COLUMN disabled_at TIMESTAMP,
+    ADD COLUMN password_reset_required BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,11 @@
+ALTER TABLE users DROP COLUMN IF EXISTS created_at;
+
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
+
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
+
+ALTER TABLE users DROP COLUMN IF EXISTS email;
+
+ALTER TABLE users DROP COLUMN IF EXISTS display_name;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_name_key;
@@ -0,0 +1,13 @@
+-- Duplicate names could be registered before, keep the oldest account and
+-- make the others unique by their id.
+UPDATE users u SET name = u.name || '-' || u.id
+WHERE EXISTS (SELECT 1 FROM users o WHERE o.name = u.name AND o.id < u.id);
+
+ALTER TABLE users ADD CONSTRAINT users_name_key UNIQUE (name);
+
+ALTER TABLE users
+    ADD COLUMN display_name TEXT,
+    ADD COLUMN email TEXT,
+    ADD COLUMN timezone TEXT NOT NULL DEFAULT 'UTC',
+    ADD COLUMN locale TEXT NOT NULL DEFAULT 'en',
+    ADD COLUMN created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
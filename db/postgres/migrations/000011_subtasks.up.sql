@@ -0,0 +1,5 @@
+ALTER TABLE tasks
+    ADD COLUMN parent_id INTEGER REFERENCES tasks(id) ON DELETE CASCADE,
+    ADD COLUMN auto_complete BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_tasks_parent_id ON tasks(parent_id);
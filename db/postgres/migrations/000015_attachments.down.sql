@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS trigger_queue_orphaned_blob ON attachments;
+
+DROP FUNCTION IF EXISTS queue_orphaned_blob();
+
+DROP TABLE IF EXISTS orphaned_blobs;
+
+DROP TABLE IF EXISTS attachments;
@@ -0,0 +1,33 @@
+CREATE TABLE attachments (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    checksum TEXT NOT NULL,
+    blob_key TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_attachments_task_id ON attachments(task_id);
+
+-- Deleted attachments, also those cascaded from deleted tasks, leave their
+-- blobs here until the blob store has removed them.
+CREATE TABLE orphaned_blobs (
+    blob_key TEXT PRIMARY KEY,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE FUNCTION queue_orphaned_blob()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO orphaned_blobs (blob_key) VALUES (OLD.blob_key) ON CONFLICT DO NOTHING;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_queue_orphaned_blob
+AFTER DELETE ON attachments
+FOR EACH ROW
+EXECUTE FUNCTION queue_orphaned_blob();
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS trigger_record_task_event ON tasks;
+
+DROP FUNCTION IF EXISTS record_task_event();
+
+DROP TABLE IF EXISTS task_events;
+
+DROP FUNCTION IF EXISTS reject_task_event_change();
@@ -0,0 +1,72 @@
+-- task_events is an append-only log of task changes. It has no foreign keys
+-- so that the history outlives the task, its project and the actor.
+CREATE TABLE task_events (
+    id BIGSERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL,
+    user_id INTEGER,
+    project_id INTEGER,
+    actor_id INTEGER,
+    action TEXT NOT NULL,
+    changes JSONB NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_task_events_task_id ON task_events(task_id);
+
+CREATE FUNCTION reject_task_event_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'task_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_reject_task_event_change
+BEFORE UPDATE OR DELETE ON task_events
+FOR EACH ROW
+EXECUTE FUNCTION reject_task_event_change();
+
+-- The actor is set per transaction with set_config('skillsrock.actor_id', ...),
+-- changes without one are made by the system.
+CREATE FUNCTION record_task_event()
+RETURNS TRIGGER AS $$
+DECLARE
+    old_row JSONB;
+    new_row JSONB;
+    task_row JSONB;
+    changes JSONB;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        task_row := to_jsonb(OLD);
+        old_row := task_row - 'id' - 'created_at' - 'updated_at';
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        task_row := to_jsonb(NEW);
+        new_row := task_row - 'id' - 'created_at' - 'updated_at';
+    END IF;
+
+    SELECT COALESCE(jsonb_agg(jsonb_build_object('field', key, 'old', old_row -> key, 'new', new_row -> key) ORDER BY key), '[]')
+    INTO changes
+    FROM jsonb_object_keys(COALESCE(new_row, old_row)) AS key
+    WHERE (old_row -> key) IS DISTINCT FROM (new_row -> key);
+
+    IF TG_OP = 'UPDATE' AND changes = '[]' THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO task_events (task_id, user_id, project_id, actor_id, action, changes)
+    VALUES (
+        (task_row ->> 'id')::INTEGER,
+        (task_row ->> 'user_id')::INTEGER,
+        (task_row ->> 'project_id')::INTEGER,
+        NULLIF(current_setting('skillsrock.actor_id', true), '')::INTEGER,
+        CASE TG_OP WHEN 'INSERT' THEN 'created' WHEN 'UPDATE' THEN 'updated' ELSE 'deleted' END,
+        changes
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_record_task_event
+AFTER INSERT OR UPDATE OR DELETE ON tasks
+FOR EACH ROW
+EXECUTE FUNCTION record_task_event();
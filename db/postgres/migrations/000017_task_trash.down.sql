@@ -0,0 +1,42 @@
+CREATE OR REPLACE FUNCTION record_task_event()
+RETURNS TRIGGER AS $$
+DECLARE
+    old_row JSONB;
+    new_row JSONB;
+    task_row JSONB;
+    changes JSONB;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        task_row := to_jsonb(OLD);
+        old_row := task_row - 'id' - 'created_at' - 'updated_at';
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        task_row := to_jsonb(NEW);
+        new_row := task_row - 'id' - 'created_at' - 'updated_at';
+    END IF;
+
+    SELECT COALESCE(jsonb_agg(jsonb_build_object('field', key, 'old', old_row -> key, 'new', new_row -> key) ORDER BY key), '[]')
+    INTO changes
+    FROM jsonb_object_keys(COALESCE(new_row, old_row)) AS key
+    WHERE (old_row -> key) IS DISTINCT FROM (new_row -> key);
+
+    IF TG_OP = 'UPDATE' AND changes = '[]' THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO task_events (task_id, user_id, project_id, actor_id, action, changes)
+    VALUES (
+        (task_row ->> 'id')::INTEGER,
+        (task_row ->> 'user_id')::INTEGER,
+        (task_row ->> 'project_id')::INTEGER,
+        NULLIF(current_setting('skillsrock.actor_id', true), '')::INTEGER,
+        CASE TG_OP WHEN 'INSERT' THEN 'created' WHEN 'UPDATE' THEN 'updated' ELSE 'deleted' END,
+        changes
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS idx_tasks_deleted_at;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS deleted_at;
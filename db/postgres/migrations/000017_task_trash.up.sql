@@ -0,0 +1,55 @@
+-- deleted_at moves a task to the trash. A task and the subtasks trashed
+-- with it share the same deleted_at, so they are restored together.
+ALTER TABLE tasks ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX idx_tasks_deleted_at ON tasks(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Moving to and from the trash is recorded as trashed and restored, deleted
+-- now means the task was purged.
+CREATE OR REPLACE FUNCTION record_task_event()
+RETURNS TRIGGER AS $$
+DECLARE
+    old_row JSONB;
+    new_row JSONB;
+    task_row JSONB;
+    changes JSONB;
+    event_action TEXT;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        task_row := to_jsonb(OLD);
+        old_row := task_row - 'id' - 'created_at' - 'updated_at';
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        task_row := to_jsonb(NEW);
+        new_row := task_row - 'id' - 'created_at' - 'updated_at';
+    END IF;
+
+    SELECT COALESCE(jsonb_agg(jsonb_build_object('field', key, 'old', old_row -> key, 'new', new_row -> key) ORDER BY key), '[]')
+    INTO changes
+    FROM jsonb_object_keys(COALESCE(new_row, old_row)) AS key
+    WHERE (old_row -> key) IS DISTINCT FROM (new_row -> key);
+
+    IF TG_OP = 'UPDATE' AND changes = '[]' THEN
+        RETURN NULL;
+    END IF;
+
+    event_action := CASE
+        WHEN TG_OP = 'INSERT' THEN 'created'
+        WHEN TG_OP = 'DELETE' THEN 'deleted'
+        WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN 'trashed'
+        WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN 'restored'
+        ELSE 'updated'
+    END;
+
+    INSERT INTO task_events (task_id, user_id, project_id, actor_id, action, changes)
+    VALUES (
+        (task_row ->> 'id')::INTEGER,
+        (task_row ->> 'user_id')::INTEGER,
+        (task_row ->> 'project_id')::INTEGER,
+        NULLIF(current_setting('skillsrock.actor_id', true), '')::INTEGER,
+        event_action,
+        changes
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,35 @@
+-- task_series holds the recurrence rule of a recurring task and the template
+-- its occurrences are created from. starts_at is the first occurrence the
+-- rule counts from, last_at the latest created one and next_at the one to
+-- create next, NULL once the rule has ended.
+CREATE TABLE task_series (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    project_id INTEGER REFERENCES projects(id) ON DELETE CASCADE,
+    parent_id INTEGER REFERENCES tasks(id) ON DELETE SET NULL,
+    rule TEXT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT,
+    priority task_priority NOT NULL,
+    auto_complete BOOLEAN NOT NULL DEFAULT FALSE,
+    starts_at TIMESTAMP NOT NULL,
+    last_at TIMESTAMP NOT NULL,
+    next_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_task_series_next_at ON task_series(next_at) WHERE next_at IS NOT NULL;
+
+CREATE TABLE task_series_labels (
+    series_id INTEGER NOT NULL REFERENCES task_series(id) ON DELETE CASCADE,
+    label_id INTEGER NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+    PRIMARY KEY (series_id, label_id)
+);
+
+-- occurs_at is the slot of the occurrence in its series, due_date may be
+-- moved away from it.
+ALTER TABLE tasks
+    ADD COLUMN series_id INTEGER REFERENCES task_series(id) ON DELETE SET NULL,
+    ADD COLUMN occurs_at TIMESTAMP;
+
+CREATE INDEX idx_tasks_series_id ON tasks(series_id);
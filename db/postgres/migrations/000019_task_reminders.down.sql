@@ -0,0 +1,3 @@
+ALTER TABLE task_series DROP COLUMN IF EXISTS reminders;
+
+DROP TABLE IF EXISTS task_reminders;
@@ -0,0 +1,21 @@
+-- task_reminders holds the reminders of a task, minutes_before its due date.
+-- sent_for is the due date a reminder was last sent for: it is sent once per
+-- due date and again when the due date moves. attempts counts the failed
+-- deliveries since it was last sent or the task changed, attempts_for is the
+-- due date they were counted for, so that a reminder that was given up is
+-- tried again once the due date moves.
+-- claimed_until leases a reminder to the worker sending it. Reminders are
+-- claimed in a short transaction and delivered after it, so slow deliveries
+-- hold no locks. A lease that runs out makes the reminder due again.
+CREATE TABLE task_reminders (
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    minutes_before INTEGER NOT NULL CHECK (minutes_before >= 0),
+    sent_for TIMESTAMP,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    attempts_for TIMESTAMP,
+    claimed_until TIMESTAMP,
+    PRIMARY KEY (task_id, minutes_before)
+);
+
+-- Occurrences of a recurring task get the reminders of its series.
+ALTER TABLE task_series ADD COLUMN reminders INTEGER[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,22 @@
+CREATE TYPE task_status AS ENUM ('pending', 'in_progress', 'done');
+
+-- Custom statuses fall back to the former status of their category.
+ALTER TABLE tasks ALTER COLUMN status TYPE task_status USING (
+    CASE status_category(project_id, status)
+        WHEN 'done' THEN 'done'
+        WHEN 'in_progress' THEN 'in_progress'
+        ELSE 'pending'
+    END
+)::task_status;
+
+DROP FUNCTION IF EXISTS workflow_status(INTEGER, status_category);
+
+DROP FUNCTION IF EXISTS status_category(INTEGER, TEXT);
+
+DROP FUNCTION IF EXISTS workflow_of(INTEGER);
+
+DROP TABLE IF EXISTS task_transitions;
+
+DROP TABLE IF EXISTS task_statuses;
+
+DROP TYPE IF EXISTS status_category;
@@ -0,0 +1,59 @@
+CREATE TYPE status_category AS ENUM ('todo', 'in_progress', 'done');
+
+-- task_statuses holds the statuses of the workflow of a project, or of the
+-- default workflow when project_id is NULL. Projects without statuses of
+-- their own use the default workflow. position orders the statuses.
+CREATE TABLE task_statuses (
+    id SERIAL PRIMARY KEY,
+    project_id INTEGER REFERENCES projects(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    category status_category NOT NULL,
+    position INTEGER NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_task_statuses_project_name ON task_statuses(project_id, name);
+CREATE UNIQUE INDEX idx_task_statuses_default_name ON task_statuses(name) WHERE project_id IS NULL;
+
+-- task_transitions holds the status changes a workflow allows.
+CREATE TABLE task_transitions (
+    from_id INTEGER NOT NULL REFERENCES task_statuses(id) ON DELETE CASCADE,
+    to_id INTEGER NOT NULL REFERENCES task_statuses(id) ON DELETE CASCADE,
+    PRIMARY KEY (from_id, to_id)
+);
+
+-- The default workflow keeps the former statuses. Done tasks can only be
+-- reopened as in progress.
+INSERT INTO task_statuses (project_id, name, category, position) VALUES
+    (NULL, 'pending', 'todo', 0),
+    (NULL, 'in_progress', 'in_progress', 1),
+    (NULL, 'done', 'done', 2);
+
+INSERT INTO task_transitions (from_id, to_id)
+SELECT f.id, t.id FROM task_statuses f, task_statuses t
+WHERE f.project_id IS NULL AND t.project_id IS NULL AND (f.name, t.name) IN (
+    ('pending', 'in_progress'), ('pending', 'done'),
+    ('in_progress', 'pending'), ('in_progress', 'done'),
+    ('done', 'in_progress'));
+
+ALTER TABLE tasks ALTER COLUMN status TYPE TEXT USING status::text;
+
+DROP TYPE task_status;
+
+-- workflow_of returns the project when it has a workflow of its own and NULL
+-- for the default workflow.
+CREATE FUNCTION workflow_of(project INTEGER) RETURNS INTEGER AS $$
+    SELECT project WHERE EXISTS (SELECT 1 FROM task_statuses WHERE project_id = project)
+$$ LANGUAGE sql STABLE;
+
+-- status_category returns the category of a status in the workflow of the
+-- project, NULL for unknown statuses.
+CREATE FUNCTION status_category(project INTEGER, status TEXT) RETURNS status_category AS $$
+    SELECT category FROM task_statuses WHERE project_id IS NOT DISTINCT FROM workflow_of(project) AND name = status
+$$ LANGUAGE sql STABLE;
+
+-- workflow_status returns the first status of the category in the workflow
+-- of the project.
+CREATE FUNCTION workflow_status(project INTEGER, wanted status_category) RETURNS TEXT AS $$
+    SELECT name FROM task_statuses WHERE project_id IS NOT DISTINCT FROM workflow_of(project) AND category = wanted
+    ORDER BY position LIMIT 1
+$$ LANGUAGE sql STABLE;
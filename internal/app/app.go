@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -11,10 +12,16 @@ import (
 	"github.com/wazwki/skillsrock/internal/controllers/rest"
 	"github.com/wazwki/skillsrock/internal/controllers/rest/routes"
 	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
 	"github.com/wazwki/skillsrock/internal/repository"
 	"github.com/wazwki/skillsrock/internal/service"
+	"github.com/wazwki/skillsrock/pkg/blob"
+	"github.com/wazwki/skillsrock/pkg/hashutil"
 	"github.com/wazwki/skillsrock/pkg/jwtutil"
 	"github.com/wazwki/skillsrock/pkg/logger"
+	"github.com/wazwki/skillsrock/pkg/notify"
+	"github.com/wazwki/skillsrock/pkg/oidc"
+	"github.com/wazwki/skillsrock/pkg/ratelimit"
 	"go.uber.org/zap"
 
 	"github.com/labstack/echo/v4"
@@ -42,23 +49,150 @@ func New(cfg *config.Config) (*App, error) {
 		return nil, err
 	}
 
+	projectRepository := repository.NewProjectRepository(pool)
+	projectService := service.NewProjectService(projectRepository)
+	projectControllers := v1.NewProjectControllers(projectService)
+
 	taskRepository := repository.NewTaskRepository(pool, redisClient)
-	taskService := service.NewTaskService(taskRepository)
+	reminderNotifier, err := notify.New(cfg.ReminderNotifier, cfg.ReminderNotifyFile, notify.WebhookConfig{
+		URL:    cfg.WebhookURL,
+		Secret: cfg.WebhookSecret,
+	}, notify.SMTPConfig{
+		Addr:     cfg.SMTPAddr,
+		From:     cfg.SMTPFrom,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+	})
+	if err != nil {
+		logger.Error("Fail create reminder notifier", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	workflowRepository := repository.NewWorkflowRepository(pool)
+	workflowService := service.NewWorkflowService(workflowRepository, projectRepository)
+	workflowControllers := v1.NewWorkflowControllers(workflowService)
+
+	taskService := service.NewTaskService(taskRepository, projectRepository, workflowRepository, reminderNotifier, time.Duration(cfg.TrashRetention)*time.Second)
 	taskControllers := v1.NewTaskControllers(taskService)
 
-	userRepository := repository.NewUserRepository(pool)
-	userService := service.NewUserService(userRepository)
-	userControllers := v1.NewUserControllers(userService)
+	commentRepository := repository.NewCommentRepository(pool)
+	commentService := service.NewCommentService(commentRepository, taskRepository, projectRepository)
+	commentControllers := v1.NewCommentControllers(commentService)
 
-	jwt := jwtutil.NewJWTUtil(jwtutil.Config{
+	labelRepository := repository.NewLabelRepository(pool)
+	labelService := service.NewLabelService(labelRepository, projectRepository)
+	labelControllers := v1.NewLabelControllers(labelService)
+
+	jwtCfg := jwtutil.Config{
 		AccessTokenSecret:  []byte(cfg.AccessTokenSecret),
 		RefreshTokenSecret: []byte(cfg.RefreshTokenSecret),
 		AccessTokenTTL:     time.Duration(cfg.AccessTokenTTL) * time.Second,
 		RefreshTokenTTL:    time.Duration(cfg.RefreshTokenTTL) * time.Second,
+		KeyID:              cfg.JWTKeyID,
+	}
+	if cfg.JWTPrivateKeyFile != "" {
+		jwtCfg.SigningKey, err = jwtutil.LoadPrivateKey(cfg.JWTPrivateKeyFile)
+		if err != nil {
+			logger.Error("Fail load jwt signing key", zap.Error(err), zap.String("module", "skillsrock"))
+			return nil, err
+		}
+
+		jwtCfg.VerificationKeys, err = jwtutil.LoadVerificationKeys(cfg.JWTPublicKeys)
+		if err != nil {
+			logger.Error("Fail load jwt verification keys", zap.Error(err), zap.String("module", "skillsrock"))
+			return nil, err
+		}
+	}
+
+	jwt, err := jwtutil.NewJWTUtil(jwtCfg)
+	if err != nil {
+		logger.Error("Fail create jwt util", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	hasher, err := hashutil.NewHasher(hashutil.Config{
+		Algorithm: cfg.PasswordHash,
+		Argon2: hashutil.Argon2Params{
+			Memory:      uint32(cfg.Argon2Memory),
+			Iterations:  uint32(cfg.Argon2Iterations),
+			Parallelism: uint8(cfg.Argon2Parallelism),
+		},
+		BcryptCost: cfg.BcryptCost,
+	})
+	if err != nil {
+		logger.Error("Fail create password hasher", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	userRepository := repository.NewUserRepository(pool)
+	tokenRepository := repository.NewTokenRepository(redisClient)
+	attemptRepository := repository.NewLoginAttemptRepository(redisClient)
+	mfaRepository := repository.NewMFARepository(pool)
+	identityRepository := repository.NewIdentityRepository(pool)
+
+	var oidcClient *oidc.Client
+	if cfg.OIDCIssuer != "" {
+		oidcClient = oidc.New(oidc.Config{
+			Issuer:       cfg.OIDCIssuer,
+			ClientID:     cfg.OIDCClientID,
+			ClientSecret: cfg.OIDCClientSecret,
+			RedirectURL:  cfg.OIDCRedirectURL,
+			Scopes:       strings.Fields(cfg.OIDCScopes),
+		})
+	}
+
+	userService := service.NewUserService(userRepository, tokenRepository, attemptRepository, mfaRepository, identityRepository, jwt, hasher, oidcClient, service.LockoutConfig{
+		MaxFailures: cfg.LoginMaxFailures,
+		Duration:    time.Duration(cfg.LoginLockout) * time.Second,
 	})
+	userControllers := v1.NewUserControllers(userService)
+
+	mfaService := service.NewMFAService(userRepository, mfaRepository, tokenRepository, hasher)
+	mfaControllers := v1.NewMFAControllers(mfaService)
+
+	// Reset tokens are secrets, so they are never sent to the webhook or mail
+	// server configured for reminders.
+	notifier, err := notify.NewLocal(cfg.Notifier, cfg.NotifyFile)
+	if err != nil {
+		logger.Error("Fail create notifier", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	passwordResetRepository := repository.NewPasswordResetRepository(pool)
+	passwordService := service.NewPasswordService(userRepository, passwordResetRepository, tokenRepository, hasher, notifier, time.Duration(cfg.PasswordResetTTL)*time.Second)
+	passwordControllers := v1.NewPasswordControllers(passwordService)
+
+	adminService := service.NewAdminService(userRepository, tokenRepository)
+	adminControllers := v1.NewAdminControllers(adminService)
+
+	apiKeyRepository := repository.NewAPIKeyRepository(pool)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepository)
+	apiKeyControllers := v1.NewAPIKeyControllers(apiKeyService)
+
+	blobs, err := blob.New(cfg.BlobStore, cfg.BlobDir, blob.S3Config{
+		Endpoint:  cfg.S3Endpoint,
+		Region:    cfg.S3Region,
+		Bucket:    cfg.S3Bucket,
+		AccessKey: cfg.S3AccessKey,
+		SecretKey: cfg.S3SecretKey,
+		PathStyle: cfg.S3PathStyle,
+	})
+	if err != nil {
+		logger.Error("Fail create blob store", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	attachmentRepository := repository.NewAttachmentRepository(pool)
+	attachmentService := service.NewAttachmentService(attachmentRepository, taskRepository, projectRepository, blobs, domain.AttachmentLimits{
+		MaxSize: cfg.AttachmentMaxSize,
+		Types:   cfg.AttachmentTypes,
+	})
+	attachmentControllers := v1.NewAttachmentControllers(attachmentService, cfg.AttachmentMaxSize)
+
+	limiter := ratelimit.New(redisClient, "ratelimit:", cfg.RateLimitRequests, time.Duration(cfg.RateLimitWindow)*time.Second)
 
-	srv := rest.NewEchoServer(cfg, jwt)
-	routes.RegisterRoutes(srv, taskControllers, userControllers)
+	srv := rest.NewEchoServer(cfg, userService, apiKeyService, jwt, limiter)
+	routes.RegisterRoutes(srv, taskControllers, userControllers, projectControllers, apiKeyControllers, adminControllers, passwordControllers, mfaControllers, labelControllers, commentControllers, attachmentControllers, workflowControllers)
 
 	return &App{server: srv, migrateDSN: cfg.DBdsn, pool: pool}, nil
 }
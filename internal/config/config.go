@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 )
 
 type Config struct {
@@ -15,13 +16,65 @@ type Config struct {
 	RefreshTokenSecret []byte
 	AccessTokenTTL     int
 	RefreshTokenTTL    int
+	JWTPrivateKeyFile  string
+	JWTKeyID           string
+	JWTPublicKeys      string
 	RedisHost          string
 	RedisPort          string
 	RedisPassword      string
 	RedisDBNumber      int
+	RateLimitRequests  int
+	RateLimitWindow    int
+	LoginMaxFailures   int
+	LoginLockout       int
+	PasswordResetTTL   int
+	Notifier           string
+	NotifyFile         string
+	ReminderNotifier   string
+	ReminderNotifyFile string
+	WebhookURL         string
+	WebhookSecret      string
+	SMTPAddr           string
+	SMTPFrom           string
+	SMTPUsername       string
+	SMTPPassword       string
+	PasswordHash       string
+	Argon2Memory       int
+	Argon2Iterations   int
+	Argon2Parallelism  int
+	BcryptCost         int
+	OIDCIssuer         string
+	OIDCClientID       string
+	OIDCClientSecret   string
+	OIDCRedirectURL    string
+	OIDCScopes         string
+	BlobStore          string
+	BlobDir            string
+	S3Endpoint         string
+	S3Region           string
+	S3Bucket           string
+	S3AccessKey        string
+	S3SecretKey        string
+	S3PathStyle        bool
+	AttachmentMaxSize  int64
+	AttachmentTypes    []string
+	TrashRetention     int
 	Debug              bool
 }
 
+// defaultAttachmentTypes are the content types http.DetectContentType reports
+// for images, PDF, plain text and zip based office documents.
+var defaultAttachmentTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp", "application/pdf", "text/plain", "application/zip"}
+
+// intFromEnv reads an optional integer, falling back to def when unset.
+func intFromEnv(name string, def int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return def, nil
+	}
+	return strconv.Atoi(value)
+}
+
 func LoadFromEnv() (*Config, error) {
 	attl, err := strconv.Atoi(os.Getenv("ACCESS_TOKEN_TTL"))
 	if err != nil {
@@ -39,6 +92,64 @@ func LoadFromEnv() (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	rateLimit, err := intFromEnv("RATE_LIMIT_REQUESTS", 10)
+	if err != nil {
+		return nil, err
+	}
+	rateWindow, err := intFromEnv("RATE_LIMIT_WINDOW", 60)
+	if err != nil {
+		return nil, err
+	}
+	maxFailures, err := intFromEnv("LOGIN_MAX_FAILURES", 5)
+	if err != nil {
+		return nil, err
+	}
+	lockout, err := intFromEnv("LOGIN_LOCKOUT", 60)
+	if err != nil {
+		return nil, err
+	}
+	resetTTL, err := intFromEnv("PASSWORD_RESET_TTL", 900)
+	if err != nil {
+		return nil, err
+	}
+	argonMemory, err := intFromEnv("ARGON2_MEMORY", 64*1024)
+	if err != nil {
+		return nil, err
+	}
+	argonIterations, err := intFromEnv("ARGON2_ITERATIONS", 3)
+	if err != nil {
+		return nil, err
+	}
+	argonParallelism, err := intFromEnv("ARGON2_PARALLELISM", 2)
+	if err != nil {
+		return nil, err
+	}
+	bcryptCost, err := intFromEnv("BCRYPT_COST", 10)
+	if err != nil {
+		return nil, err
+	}
+	s3PathStyle := false
+	if value := os.Getenv("S3_PATH_STYLE"); value != "" {
+		if s3PathStyle, err = strconv.ParseBool(value); err != nil {
+			return nil, err
+		}
+	}
+	attachmentMaxSize, err := intFromEnv("ATTACHMENT_MAX_SIZE", 10<<20)
+	if err != nil {
+		return nil, err
+	}
+	blobDir := os.Getenv("BLOB_DIR")
+	if blobDir == "" {
+		blobDir = "data/blobs"
+	}
+	trashRetention, err := intFromEnv("TRASH_RETENTION", 30*24*60*60)
+	if err != nil {
+		return nil, err
+	}
+	attachmentTypes := defaultAttachmentTypes
+	if value := os.Getenv("ATTACHMENT_TYPES"); value != "" {
+		attachmentTypes = strings.Split(value, ",")
+	}
 
 	cfg := &Config{
 		Host:     os.Getenv("HOST"),
@@ -56,6 +167,45 @@ func LoadFromEnv() (*Config, error) {
 		RefreshTokenSecret: []byte(os.Getenv("JWT_REFRESH_SECRET")),
 		AccessTokenTTL:     attl,
 		RefreshTokenTTL:    rttl,
+		JWTPrivateKeyFile:  os.Getenv("JWT_PRIVATE_KEY_FILE"),
+		JWTKeyID:           os.Getenv("JWT_KEY_ID"),
+		JWTPublicKeys:      os.Getenv("JWT_PUBLIC_KEYS"),
+		RateLimitRequests:  rateLimit,
+		RateLimitWindow:    rateWindow,
+		LoginMaxFailures:   maxFailures,
+		LoginLockout:       lockout,
+		PasswordResetTTL:   resetTTL,
+		Notifier:           os.Getenv("NOTIFIER"),
+		NotifyFile:         os.Getenv("NOTIFY_FILE"),
+		ReminderNotifier:   os.Getenv("REMINDER_NOTIFIER"),
+		ReminderNotifyFile: os.Getenv("REMINDER_NOTIFY_FILE"),
+		WebhookURL:         os.Getenv("WEBHOOK_URL"),
+		WebhookSecret:      os.Getenv("WEBHOOK_SECRET"),
+		SMTPAddr:           os.Getenv("SMTP_ADDR"),
+		SMTPFrom:           os.Getenv("SMTP_FROM"),
+		SMTPUsername:       os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:       os.Getenv("SMTP_PASSWORD"),
+		PasswordHash:       os.Getenv("PASSWORD_HASH"),
+		Argon2Memory:       argonMemory,
+		Argon2Iterations:   argonIterations,
+		Argon2Parallelism:  argonParallelism,
+		BcryptCost:         bcryptCost,
+		OIDCIssuer:         os.Getenv("OIDC_ISSUER"),
+		OIDCClientID:       os.Getenv("OIDC_CLIENT_ID"),
+		OIDCClientSecret:   os.Getenv("OIDC_CLIENT_SECRET"),
+		OIDCRedirectURL:    os.Getenv("OIDC_REDIRECT_URL"),
+		OIDCScopes:         os.Getenv("OIDC_SCOPES"),
+		BlobStore:          os.Getenv("BLOB_STORE"),
+		BlobDir:            blobDir,
+		S3Endpoint:         os.Getenv("S3_ENDPOINT"),
+		S3Region:           os.Getenv("S3_REGION"),
+		S3Bucket:           os.Getenv("S3_BUCKET"),
+		S3AccessKey:        os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:        os.Getenv("S3_SECRET_KEY"),
+		S3PathStyle:        s3PathStyle,
+		AttachmentMaxSize:  int64(attachmentMaxSize),
+		AttachmentTypes:    attachmentTypes,
+		TrashRetention:     trashRetention,
 		Debug:              debug,
 	}
 
@@ -0,0 +1,13 @@
+package rest
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type AdminControllersInterface interface {
+	GetUsers(c echo.Context) error
+	SetUserRole(c echo.Context) error
+	DisableUser(c echo.Context) error
+	EnableUser(c echo.Context) error
+	ForcePasswordReset(c echo.Context) error
+}
@@ -0,0 +1,11 @@
+package rest
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type APIKeyControllersInterface interface {
+	GetAPIKeys(c echo.Context) error
+	CreateAPIKey(c echo.Context) error
+	RevokeAPIKey(c echo.Context) error
+}
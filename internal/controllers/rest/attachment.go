@@ -0,0 +1,12 @@
+package rest
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type AttachmentControllersInterface interface {
+	GetAttachments(c echo.Context) error
+	UploadAttachment(c echo.Context) error
+	DownloadAttachment(c echo.Context) error
+	DeleteAttachment(c echo.Context) error
+}
@@ -0,0 +1,12 @@
+package rest
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type CommentControllersInterface interface {
+	GetComments(c echo.Context) error
+	CreateComment(c echo.Context) error
+	UpdateComment(c echo.Context) error
+	DeleteComment(c echo.Context) error
+}
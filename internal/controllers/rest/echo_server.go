@@ -10,22 +10,29 @@ import (
 	echoSwagger "github.com/swaggo/echo-swagger"
 	"github.com/wazwki/skillsrock/internal/config"
 	"github.com/wazwki/skillsrock/internal/controllers/rest/middlewares"
+	"github.com/wazwki/skillsrock/internal/service"
 	"github.com/wazwki/skillsrock/pkg/jwtutil"
+	"github.com/wazwki/skillsrock/pkg/ratelimit"
 )
 
-func NewEchoServer(cfg *config.Config, jwt *jwtutil.JWTUtil) *echo.Echo {
+func NewEchoServer(cfg *config.Config, users service.UserServiceInterface, apiKeys service.APIKeyServiceInterface, jwt *jwtutil.JWTUtil, limiter *ratelimit.Limiter) *echo.Echo {
 	srv := echo.New()
 	srv.HideBanner = true
 	srv.GET("/swagger/*", echoSwagger.WrapHandler)
 	srv.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
+	srv.GET("/.well-known/jwks.json", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, jwt.JWKS())
+	})
 
 	srv.Use(
 		echo.MiddlewareFunc(middlewares.MetricsMiddleware()),
 		echo.MiddlewareFunc(middlewares.LoggerMiddleware()),
+		echo.MiddlewareFunc(middlewares.ClientMiddleware()),
+		echo.MiddlewareFunc(middlewares.RateLimitMiddleware(limiter)),
 	)
 	if !cfg.Debug {
 		srv.Use(
-			echo.MiddlewareFunc(middlewares.JWTMiddleware(cfg, jwt)),
+			echo.MiddlewareFunc(middlewares.JWTMiddleware(users, apiKeys)),
 		)
 	}
 
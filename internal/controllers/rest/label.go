@@ -0,0 +1,12 @@
+package rest
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type LabelControllersInterface interface {
+	GetLabels(c echo.Context) error
+	CreateLabel(c echo.Context) error
+	UpdateLabel(c echo.Context) error
+	DeleteLabel(c echo.Context) error
+}
@@ -0,0 +1,11 @@
+package rest
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type MFAControllersInterface interface {
+	EnrolTOTP(c echo.Context) error
+	ConfirmTOTP(c echo.Context) error
+	DisableTOTP(c echo.Context) error
+}
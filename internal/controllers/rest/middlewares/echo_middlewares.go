@@ -1,51 +1,171 @@
 package middlewares
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
-	"github.com/wazwki/skillsrock/internal/config"
-	"github.com/wazwki/skillsrock/pkg/jwtutil"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service"
 	"github.com/wazwki/skillsrock/pkg/logger"
 	"github.com/wazwki/skillsrock/pkg/metrics"
+	"github.com/wazwki/skillsrock/pkg/ratelimit"
 	"go.uber.org/zap"
 )
 
-func JWTMiddleware(cfg *config.Config, jwt *jwtutil.JWTUtil) echo.MiddlewareFunc {
+func JWTMiddleware(users service.UserServiceInterface, apiKeys service.APIKeyServiceInterface) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			if c.Path() == "/api/v1/auth/register" {
+			switch c.Path() {
+			case "/api/v1/auth/register", "/api/v1/auth/login", "/api/v1/auth/mfa", "/api/v1/auth/refresh",
+				"/api/v1/auth/oidc/login", "/api/v1/auth/oidc/callback", "/api/v1/auth/password/forgot", "/api/v1/auth/password/reset", "/.well-known/jwks.json":
 				return next(c)
 			}
 
-			if c.Path() == "/api/v1/auth/login" {
-				token, err := jwt.GenerateAccessToken(c.Request().Context())
+			if rawKey := apiKey(c); rawKey != "" {
+				key, err := apiKeys.Authenticate(c.Request().Context(), rawKey)
 				if err != nil {
+					if errors.Is(err, domain.InvalidToken) {
+						return echo.ErrUnauthorized
+					}
 					return err
 				}
 
-				c.SetCookie(&http.Cookie{
-					Name:     "Authorization",
-					Value:    token,
-					Expires:  time.Now().Add(time.Second * time.Duration(cfg.AccessTokenTTL)),
-					HttpOnly: true,
-					SameSite: http.SameSiteLaxMode,
-				})
+				ctx := domain.ContextWithUserID(c.Request().Context(), key.UserID)
+				ctx = domain.ContextWithRole(ctx, key.UserRole)
+				c.SetRequest(c.Request().WithContext(domain.ContextWithScopes(ctx, key.Scopes)))
 
 				return next(c)
 			}
 
-			authHeader := c.Request().Header.Get("Authorization")
-			if authHeader == "" {
+			tokenStr := bearerToken(c)
+			if tokenStr == "" {
 				return echo.ErrUnauthorized
 			}
 
-			tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
-			_, err := jwt.ValidateToken(c.Request().Context(), tokenStr)
+			claims, err := users.Authenticate(c.Request().Context(), tokenStr)
 			if err != nil {
-				return echo.ErrUnauthorized
+				if errors.Is(err, domain.InvalidToken) {
+					return echo.ErrUnauthorized
+				}
+				return err
+			}
+
+			c.Set("claims", claims)
+			ctx := domain.ContextWithUserID(c.Request().Context(), claims.UserID)
+			ctx = domain.ContextWithRole(ctx, claims.Role)
+			c.SetRequest(c.Request().WithContext(domain.ContextWithSessionID(ctx, claims.Family)))
+
+			return next(c)
+		}
+	}
+}
+
+// ClientMiddleware records the client address and user agent in the request
+// context for session tracking.
+func ClientMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := domain.ContextWithClient(c.Request().Context(), c.RealIP(), c.Request().UserAgent())
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+// RequireScope rejects requests made with an API key that lacks scope.
+// Requests authenticated with a JWT are let through.
+func RequireScope(scope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !domain.HasScope(c.Request().Context(), scope) {
+				return c.JSON(http.StatusForbidden, echo.Map{"error": "Insufficient scope"})
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireRole rejects requests of users whose role is below role.
+func RequireRole(role string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !domain.UserRoleAtLeast(domain.RoleFromContext(c.Request().Context()), role) {
+				return c.JSON(http.StatusForbidden, echo.Map{"error": "Forbidden"})
+			}
+			return next(c)
+		}
+	}
+}
+
+// apiKey takes the key from the X-API-Key header or an "ApiKey" Authorization header.
+func apiKey(c echo.Context) string {
+	if key := c.Request().Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+
+	if key, ok := strings.CutPrefix(c.Request().Header.Get("Authorization"), "ApiKey "); ok {
+		return key
+	}
+	return ""
+}
+
+// bearerToken takes the token from the Authorization header, falling back
+// to the cookie set on login.
+func bearerToken(c echo.Context) string {
+	if authHeader := c.Request().Header.Get("Authorization"); authHeader != "" {
+		return strings.TrimPrefix(authHeader, "Bearer ")
+	}
+
+	cookie, err := c.Cookie("Authorization")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(cookie.Value, "Bearer ")
+}
+
+// rateLimitedPaths are the unauthenticated endpoints open to credential guessing.
+var rateLimitedPaths = map[string]bool{
+	"/api/v1/auth/register":        true,
+	"/api/v1/auth/login":           true,
+	"/api/v1/auth/mfa":             true,
+	"/api/v1/auth/oidc/callback":   true,
+	"/api/v1/auth/password/forgot": true,
+	"/api/v1/auth/password/reset":  true,
+}
+
+// RateLimitMiddleware limits requests to the auth endpoints per client IP and
+// per username from the request body. Limiter errors are logged and the
+// request is let through.
+func RateLimitMiddleware(limiter *ratelimit.Limiter) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			path := c.Path()
+			if !rateLimitedPaths[path] {
+				return next(c)
+			}
+
+			keys := []string{"ip:" + c.RealIP() + ":" + path}
+			if name := requestUserName(c); name != "" {
+				keys = append(keys, "user:"+name+":"+path)
+			}
+
+			for _, key := range keys {
+				allowed, retryAfter, err := limiter.Allow(c.Request().Context(), key)
+				if err != nil {
+					logger.Error("Failed to check rate limit", zap.Error(err), zap.String("module", "skillsrock"))
+					break
+				}
+				if !allowed {
+					metrics.BlockedAuthAttempts.WithLabelValues(path, "rate_limit").Inc()
+					c.Response().Header().Set("Retry-After", ratelimit.RetryAfter(retryAfter))
+					return c.JSON(http.StatusTooManyRequests, echo.Map{"error": "Too many requests"})
+				}
 			}
 
 			return next(c)
@@ -53,6 +173,24 @@ func JWTMiddleware(cfg *config.Config, jwt *jwtutil.JWTUtil) echo.MiddlewareFunc
 	}
 }
 
+// requestUserName peeks at the name field of a JSON body and restores the
+// body for the handler.
+func requestUserName(c echo.Context) string {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return ""
+	}
+	c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+	var user struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(body, &user); err != nil {
+		return ""
+	}
+	return user.Name
+}
+
 func MetricsMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -0,0 +1,81 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/wazwki/skillsrock/internal/controllers/rest/middlewares"
+	"github.com/wazwki/skillsrock/pkg/ratelimit"
+)
+
+func newRateLimited(t *testing.T, limit int) echo.HandlerFunc {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	limiter := ratelimit.New(client, "ratelimit:", limit, time.Minute)
+	return middlewares.RateLimitMiddleware(limiter)(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+}
+
+// request runs handler for a request to path from ip with body.
+func request(handler echo.HandlerFunc, path, ip, body string) *httptest.ResponseRecorder {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderXRealIP, ip)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath(path)
+
+	_ = handler(c)
+	return rec
+}
+
+func TestRateLimitPerIP(t *testing.T) {
+	handler := newRateLimited(t, 2)
+
+	for range 2 {
+		rec := request(handler, "/api/v1/auth/login", "10.0.0.1", `{"name": "test"}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	// A new name from the same address is still blocked.
+	rec := request(handler, "/api/v1/auth/login", "10.0.0.1", `{"name": "other"}`)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+
+	// The window is per endpoint.
+	rec = request(handler, "/api/v1/auth/register", "10.0.0.1", `{"name": "other"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRateLimitPerUserName(t *testing.T) {
+	handler := newRateLimited(t, 2)
+
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2"} {
+		rec := request(handler, "/api/v1/auth/login", ip, `{"name": "test"}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	rec := request(handler, "/api/v1/auth/login", "10.0.0.3", `{"name": "test"}`)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+}
+
+func TestRateLimitOtherPaths(t *testing.T) {
+	handler := newRateLimited(t, 1)
+
+	for range 3 {
+		rec := request(handler, "/api/v1/tasks", "10.0.0.1", `{"title": "test"}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
@@ -0,0 +1,11 @@
+package rest
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type PasswordControllersInterface interface {
+	ChangePassword(c echo.Context) error
+	ForgotPassword(c echo.Context) error
+	ResetPassword(c echo.Context) error
+}
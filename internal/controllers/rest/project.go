@@ -0,0 +1,17 @@
+package rest
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type ProjectControllersInterface interface {
+	GetProjects(c echo.Context) error
+	CreateProject(c echo.Context) error
+	GetProject(c echo.Context) error
+	UpdateProject(c echo.Context) error
+	DeleteProject(c echo.Context) error
+	GetMembers(c echo.Context) error
+	AddMember(c echo.Context) error
+	UpdateMember(c echo.Context) error
+	RemoveMember(c echo.Context) error
+}
@@ -3,20 +3,96 @@ package routes
 import (
 	"github.com/labstack/echo/v4"
 	"github.com/wazwki/skillsrock/internal/controllers/rest"
+	"github.com/wazwki/skillsrock/internal/controllers/rest/middlewares"
+	"github.com/wazwki/skillsrock/internal/domain"
 )
 
-func RegisterRoutes(e *echo.Echo, taskControllers rest.TaskControllersInterface, userControllers rest.UserControllersInterface) {
+func RegisterRoutes(e *echo.Echo, taskControllers rest.TaskControllersInterface, userControllers rest.UserControllersInterface, projectControllers rest.ProjectControllersInterface, apiKeyControllers rest.APIKeyControllersInterface, adminControllers rest.AdminControllersInterface, passwordControllers rest.PasswordControllersInterface, mfaControllers rest.MFAControllersInterface, labelControllers rest.LabelControllersInterface, commentControllers rest.CommentControllersInterface, attachmentControllers rest.AttachmentControllersInterface, workflowControllers rest.WorkflowControllersInterface) {
 	api := e.Group("/api")
 	v1 := api.Group("/v1")
 
+	// Read-only users may use every read route but no write route.
+	member := middlewares.RequireRole(domain.UserRoleMember)
+
+	tasksRead := middlewares.RequireScope(domain.ScopeTasksRead)
+	tasksWrite := []echo.MiddlewareFunc{middlewares.RequireScope(domain.ScopeTasksWrite), member}
+	analyticsRead := middlewares.RequireScope(domain.ScopeAnalyticsRead)
+	projectsRead := middlewares.RequireScope(domain.ScopeProjectsRead)
+	projectsWrite := []echo.MiddlewareFunc{middlewares.RequireScope(domain.ScopeProjectsWrite), member}
+
 	v1.POST("/auth/register", userControllers.Register)
 	v1.POST("/auth/login", userControllers.Login)
+	v1.GET("/auth/oidc/login", userControllers.OIDCLogin)
+	v1.GET("/auth/oidc/callback", userControllers.OIDCCallback)
+	v1.POST("/auth/mfa", userControllers.VerifyMFA)
+	v1.POST("/auth/refresh", userControllers.Refresh)
+	v1.POST("/auth/logout", userControllers.Logout)
+	v1.POST("/auth/logout/all", userControllers.LogoutAll)
+	v1.POST("/auth/password/forgot", passwordControllers.ForgotPassword)
+	v1.POST("/auth/password/reset", passwordControllers.ResetPassword)
+
+	v1.GET("/users/me", userControllers.GetMe)
+	v1.PUT("/users/me", userControllers.UpdateMe)
+	v1.DELETE("/users/me", userControllers.DeleteMe)
+	v1.GET("/users/me/sessions", userControllers.GetSessions)
+	v1.DELETE("/users/me/sessions/:id", userControllers.RevokeSession)
+	v1.POST("/users/me/identities/oidc", userControllers.LinkOIDC)
+	v1.PUT("/users/me/password", passwordControllers.ChangePassword)
+	v1.POST("/users/me/mfa/totp", mfaControllers.EnrolTOTP)
+	v1.POST("/users/me/mfa/totp/confirm", mfaControllers.ConfirmTOTP)
+	v1.DELETE("/users/me/mfa/totp", mfaControllers.DisableTOTP)
+
+	v1.GET("/api-keys", apiKeyControllers.GetAPIKeys)
+	v1.POST("/api-keys", apiKeyControllers.CreateAPIKey)
+	v1.DELETE("/api-keys/:id", apiKeyControllers.RevokeAPIKey)
+
+	v1.GET("/tasks", taskControllers.GetTasks, tasksRead)
+	v1.POST("/tasks", taskControllers.CreateTask, tasksWrite...)
+	v1.PUT("/tasks/:id", taskControllers.UpdateTask, tasksWrite...)
+	v1.DELETE("/tasks/:id", taskControllers.DeleteTask, tasksWrite...)
+	v1.GET("/tasks/trash", taskControllers.GetTrash, tasksRead)
+	v1.POST("/tasks/:id/restore", taskControllers.RestoreTask, tasksWrite...)
+	v1.GET("/tasks/:id/subtasks", taskControllers.GetSubtasks, tasksRead)
+	v1.GET("/tasks/:id/tree", taskControllers.GetTaskTree, tasksRead)
+	v1.PUT("/tasks/:id/parent", taskControllers.SetParent, tasksWrite...)
+	v1.GET("/tasks/:id/dependencies", taskControllers.GetDependencies, tasksRead)
+	v1.POST("/tasks/:id/dependencies", taskControllers.AddDependency, tasksWrite...)
+	v1.DELETE("/tasks/:id/dependencies/:blocker_id", taskControllers.RemoveDependency, tasksWrite...)
+	v1.GET("/tasks/:id/critical-path", taskControllers.GetCriticalPath, tasksRead)
+	v1.GET("/tasks/:id/history", taskControllers.GetTaskHistory, tasksRead)
+	v1.GET("/tasks/:id/comments", commentControllers.GetComments, tasksRead)
+	v1.POST("/tasks/:id/comments", commentControllers.CreateComment, tasksWrite...)
+	v1.PUT("/tasks/:id/comments/:comment_id", commentControllers.UpdateComment, tasksWrite...)
+	v1.DELETE("/tasks/:id/comments/:comment_id", commentControllers.DeleteComment, tasksWrite...)
+	v1.GET("/tasks/:id/attachments", attachmentControllers.GetAttachments, tasksRead)
+	v1.POST("/tasks/:id/attachments", attachmentControllers.UploadAttachment, tasksWrite...)
+	v1.GET("/tasks/:id/attachments/:attachment_id", attachmentControllers.DownloadAttachment, tasksRead)
+	v1.DELETE("/tasks/:id/attachments/:attachment_id", attachmentControllers.DeleteAttachment, tasksWrite...)
+	v1.GET("/labels", labelControllers.GetLabels, tasksRead)
+	v1.POST("/labels", labelControllers.CreateLabel, tasksWrite...)
+	v1.PUT("/labels/:id", labelControllers.UpdateLabel, tasksWrite...)
+	v1.DELETE("/labels/:id", labelControllers.DeleteLabel, tasksWrite...)
+	v1.GET("/workflow", workflowControllers.GetWorkflow, tasksRead)
+	v1.PUT("/workflow", workflowControllers.SetWorkflow, tasksWrite...)
+	v1.DELETE("/workflow", workflowControllers.ResetWorkflow, tasksWrite...)
+	v1.GET("/analytics", taskControllers.GetAnalytics, analyticsRead)
+	v1.POST("/tasks/import", taskControllers.ImportTasks, tasksWrite...)
+	v1.GET("/tasks/export", taskControllers.ExportTasks, tasksRead)
+
+	v1.GET("/projects", projectControllers.GetProjects, projectsRead)
+	v1.POST("/projects", projectControllers.CreateProject, projectsWrite...)
+	v1.GET("/projects/:id", projectControllers.GetProject, projectsRead)
+	v1.PUT("/projects/:id", projectControllers.UpdateProject, projectsWrite...)
+	v1.DELETE("/projects/:id", projectControllers.DeleteProject, projectsWrite...)
+	v1.GET("/projects/:id/members", projectControllers.GetMembers, projectsRead)
+	v1.POST("/projects/:id/members", projectControllers.AddMember, projectsWrite...)
+	v1.PUT("/projects/:id/members/:user_id", projectControllers.UpdateMember, projectsWrite...)
+	v1.DELETE("/projects/:id/members/:user_id", projectControllers.RemoveMember, projectsWrite...)
 
-	v1.GET("/tasks", taskControllers.GetTasks)
-	v1.POST("/tasks", taskControllers.CreateTask)
-	v1.PUT("/tasks/:id", taskControllers.UpdateTask)
-	v1.DELETE("/tasks/:id", taskControllers.DeleteTask)
-	v1.GET("/analytics", taskControllers.GetAnalytics)
-	v1.POST("/tasks/import", taskControllers.ImportTasks)
-	v1.GET("/tasks/export", taskControllers.ExportTasks)
+	admin := v1.Group("/admin", middlewares.RequireRole(domain.UserRoleAdmin))
+	admin.GET("/users", adminControllers.GetUsers)
+	admin.PUT("/users/:id/role", adminControllers.SetUserRole)
+	admin.POST("/users/:id/disable", adminControllers.DisableUser)
+	admin.POST("/users/:id/enable", adminControllers.EnableUser)
+	admin.POST("/users/:id/password-reset", adminControllers.ForcePasswordReset)
 }
@@ -9,7 +9,17 @@ type TaskControllersInterface interface {
 	CreateTask(c echo.Context) error
 	UpdateTask(c echo.Context) error
 	DeleteTask(c echo.Context) error
+	GetSubtasks(c echo.Context) error
+	GetTaskTree(c echo.Context) error
+	SetParent(c echo.Context) error
+	GetDependencies(c echo.Context) error
+	AddDependency(c echo.Context) error
+	RemoveDependency(c echo.Context) error
+	GetCriticalPath(c echo.Context) error
 	GetAnalytics(c echo.Context) error
 	ImportTasks(c echo.Context) error
 	ExportTasks(c echo.Context) error
+	GetTaskHistory(c echo.Context) error
+	GetTrash(c echo.Context) error
+	RestoreTask(c echo.Context) error
 }
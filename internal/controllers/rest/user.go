@@ -7,4 +7,16 @@ import (
 type UserControllersInterface interface {
 	Register(c echo.Context) error
 	Login(c echo.Context) error
+	VerifyMFA(c echo.Context) error
+	OIDCLogin(c echo.Context) error
+	OIDCCallback(c echo.Context) error
+	Refresh(c echo.Context) error
+	Logout(c echo.Context) error
+	LogoutAll(c echo.Context) error
+	GetMe(c echo.Context) error
+	UpdateMe(c echo.Context) error
+	DeleteMe(c echo.Context) error
+	GetSessions(c echo.Context) error
+	RevokeSession(c echo.Context) error
+	LinkOIDC(c echo.Context) error
 }
@@ -0,0 +1,164 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wazwki/skillsrock/internal/controllers/rest"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service"
+)
+
+type AdminServer struct {
+	service service.AdminServiceInterface
+}
+
+func NewAdminControllers(s service.AdminServiceInterface) rest.AdminControllersInterface {
+	return &AdminServer{service: s}
+}
+
+func adminError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, domain.Unauthorized):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Unauthorized"})
+	case errors.Is(err, domain.Forbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Forbidden"})
+	case errors.Is(err, domain.UserNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "User not found"})
+	case errors.Is(err, domain.InvalidUserRole):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid user role"})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": message})
+}
+
+// @Summary Get users
+// @Description Get all users, admins only
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} []domain.UserResponse
+// @Failure 403 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/admin/users [get]
+func (s *AdminServer) GetUsers(c echo.Context) error {
+	users, err := s.service.GetUsers(c.Request().Context())
+	if err != nil {
+		return adminError(c, err, "Failed to get users")
+	}
+
+	usersR := make([]*domain.UserResponse, 0, len(users))
+	for _, user := range users {
+		usersR = append(usersR, domain.UserToUserResponse(user))
+	}
+
+	return c.JSON(http.StatusOK, usersR)
+}
+
+// @Summary Set user role
+// @Description Change the role of a user (admin, member, readonly), admins only. Ends the sessions of the user so that the new role applies right away
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param role body domain.UserRoleRequest true "Role"
+// @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/admin/users/{id}/role [put]
+func (s *AdminServer) SetUserRole(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	var role *domain.UserRoleRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&role); err != nil || role == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.SetUserRole(c.Request().Context(), id, role.Role); err != nil {
+		return adminError(c, err, "Failed to set user role")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary Disable user
+// @Description Disable the account and end all of its sessions, admins only
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/admin/users/{id}/disable [post]
+func (s *AdminServer) DisableUser(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.DisableUser(c.Request().Context(), id); err != nil {
+		return adminError(c, err, "Failed to disable user")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary Enable user
+// @Description Enable a disabled account, admins only
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/admin/users/{id}/enable [post]
+func (s *AdminServer) EnableUser(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.EnableUser(c.Request().Context(), id); err != nil {
+		return adminError(c, err, "Failed to enable user")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary Force password reset
+// @Description End all sessions of the user and block login until the password is reset, admins only
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/admin/users/{id}/password-reset [post]
+func (s *AdminServer) ForcePasswordReset(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.ForcePasswordReset(c.Request().Context(), id); err != nil {
+		return adminError(c, err, "Failed to force password reset")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,95 @@
+package v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+func TestGetUsers(t *testing.T) {
+	mockService := mocks.NewAdminServiceInterface(t)
+	server := v1.NewAdminControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("GetUsers", mock.Anything).Return([]*domain.User{
+		{ID: 1, Name: "admin", Role: domain.UserRoleAdmin},
+		{ID: 2, Name: "John Doe", Role: domain.UserRoleMember, Disabled: true},
+	}, nil)
+
+	if assert.NoError(t, server.GetUsers(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var users []domain.UserResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &users))
+		assert.Len(t, users, 2)
+		assert.True(t, users[1].Disabled)
+	}
+}
+
+func TestGetUsersForbidden(t *testing.T) {
+	mockService := mocks.NewAdminServiceInterface(t)
+	server := v1.NewAdminControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("GetUsers", mock.Anything).Return(nil, domain.Forbidden)
+
+	if assert.NoError(t, server.GetUsers(c)) {
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	}
+}
+
+func TestSetUserRoleInvalid(t *testing.T) {
+	mockService := mocks.NewAdminServiceInterface(t)
+	server := v1.NewAdminControllers(mockService)
+	e := echo.New()
+
+	jsonReq, _ := json.Marshal(domain.UserRoleRequest{Role: "root"})
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/users/2/role", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+
+	mockService.On("SetUserRole", mock.Anything, 2, "root").Return(domain.InvalidUserRole)
+
+	if assert.NoError(t, server.SetUserRole(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestDisableUser(t *testing.T) {
+	mockService := mocks.NewAdminServiceInterface(t)
+	server := v1.NewAdminControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/2/disable", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+
+	mockService.On("DisableUser", mock.Anything, 2).Return(nil)
+
+	if assert.NoError(t, server.DisableUser(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
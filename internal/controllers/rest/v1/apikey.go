@@ -0,0 +1,111 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wazwki/skillsrock/internal/controllers/rest"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service"
+)
+
+type APIKeyServer struct {
+	service service.APIKeyServiceInterface
+}
+
+func NewAPIKeyControllers(s service.APIKeyServiceInterface) rest.APIKeyControllersInterface {
+	return &APIKeyServer{service: s}
+}
+
+func apiKeyError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, domain.Unauthorized):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Unauthorized"})
+	case errors.Is(err, domain.Forbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Forbidden"})
+	case errors.Is(err, domain.APIKeyNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "API key not found"})
+	case errors.Is(err, domain.InvalidScope):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid scope"})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": message})
+}
+
+// @Summary Get API keys
+// @Description Get active API keys of the user
+// @Tags API keys
+// @Accept json
+// @Produce json
+// @Success 200 {object} []domain.APIKeyResponse
+// @Failure 403 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/api-keys [get]
+func (s *APIKeyServer) GetAPIKeys(c echo.Context) error {
+	keys, err := s.service.GetAPIKeys(c.Request().Context())
+	if err != nil {
+		return apiKeyError(c, err, "Failed to get API keys")
+	}
+
+	keysR := make([]*domain.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		keysR = append(keysR, domain.APIKeyToAPIKeyResponse(key))
+	}
+
+	return c.JSON(http.StatusOK, keysR)
+}
+
+// @Summary Create API key
+// @Description Create API key limited to scopes (tasks:read, tasks:write, analytics:read, projects:read, projects:write). The key is returned only once
+// @Tags API keys
+// @Accept json
+// @Produce json
+// @Param key body domain.APIKeyRequest true "API key"
+// @Success 201 {object} domain.CreatedAPIKeyResponse
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/api-keys [post]
+func (s *APIKeyServer) CreateAPIKey(c echo.Context) error {
+	var key *domain.APIKeyRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&key); err != nil || key == nil || key.Name == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	created, rawKey, err := s.service.CreateAPIKey(c.Request().Context(), domain.APIKeyFromAPIKeyRequest(key))
+	if err != nil {
+		return apiKeyError(c, err, "Failed to create API key")
+	}
+
+	return c.JSON(http.StatusCreated, domain.CreatedAPIKeyResponse{
+		APIKeyResponse: *domain.APIKeyToAPIKeyResponse(created),
+		Key:            rawKey,
+	})
+}
+
+// @Summary Revoke API key
+// @Description Revoke API key
+// @Tags API keys
+// @Accept json
+// @Produce json
+// @Param id path int true "API key ID"
+// @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/api-keys/{id} [delete]
+func (s *APIKeyServer) RevokeAPIKey(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.RevokeAPIKey(c.Request().Context(), id); err != nil {
+		return apiKeyError(c, err, "Failed to revoke API key")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
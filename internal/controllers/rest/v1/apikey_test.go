@@ -0,0 +1,80 @@
+package v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	mockService := mocks.NewAPIKeyServiceInterface(t)
+	server := v1.NewAPIKeyControllers(mockService)
+	e := echo.New()
+
+	keyReq := domain.APIKeyRequest{Name: "ci", Scopes: []string{domain.ScopeTasksWrite}}
+	jsonReq, _ := json.Marshal(keyReq)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/api-keys", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("CreateAPIKey", mock.Anything, &domain.APIKey{Name: "ci", Scopes: []string{domain.ScopeTasksWrite}}).
+		Return(&domain.APIKey{ID: 1, Name: "ci", Prefix: "abcd1234", Scopes: []string{domain.ScopeTasksWrite}}, "sr_abcd1234_secret", nil)
+
+	if assert.NoError(t, server.CreateAPIKey(c)) {
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var resp domain.CreatedAPIKeyResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "sr_abcd1234_secret", resp.Key)
+		assert.Equal(t, "abcd1234", resp.Prefix)
+	}
+}
+
+func TestCreateAPIKeyInvalidScope(t *testing.T) {
+	mockService := mocks.NewAPIKeyServiceInterface(t)
+	server := v1.NewAPIKeyControllers(mockService)
+	e := echo.New()
+
+	keyReq := domain.APIKeyRequest{Name: "ci", Scopes: []string{"admin"}}
+	jsonReq, _ := json.Marshal(keyReq)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/api-keys", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("CreateAPIKey", mock.Anything, mock.Anything).Return(nil, "", domain.InvalidScope)
+
+	if assert.NoError(t, server.CreateAPIKey(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestRevokeAPIKeyNotFound(t *testing.T) {
+	mockService := mocks.NewAPIKeyServiceInterface(t)
+	server := v1.NewAPIKeyControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/api-keys/5", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("5")
+
+	mockService.On("RevokeAPIKey", mock.Anything, 5).Return(domain.APIKeyNotFound)
+
+	if assert.NoError(t, server.RevokeAPIKey(c)) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+}
@@ -0,0 +1,206 @@
+package v1
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wazwki/skillsrock/internal/controllers/rest"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service"
+)
+
+const (
+	// uploadTimeout replaces the short read timeout of the server for uploads.
+	uploadTimeout = 5 * time.Minute
+	// multipartOverhead leaves room for the multipart headers around the file.
+	multipartOverhead = 64 << 10
+)
+
+type AttachmentServer struct {
+	service service.AttachmentServiceInterface
+	maxSize int64
+}
+
+func NewAttachmentControllers(s service.AttachmentServiceInterface, maxSize int64) rest.AttachmentControllersInterface {
+	return &AttachmentServer{service: s, maxSize: maxSize}
+}
+
+func attachmentError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, domain.Unauthorized):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Unauthorized"})
+	case errors.Is(err, domain.Forbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Forbidden"})
+	case errors.Is(err, domain.TaskNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Task not found"})
+	case errors.Is(err, domain.ProjectNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found"})
+	case errors.Is(err, domain.AttachmentNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Attachment not found"})
+	case errors.Is(err, domain.InvalidAttachment):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid attachment"})
+	case errors.Is(err, domain.AttachmentTooLarge):
+		return c.JSON(http.StatusRequestEntityTooLarge, echo.Map{"error": "Attachment too large"})
+	case errors.Is(err, domain.UnsupportedAttachmentType):
+		return c.JSON(http.StatusUnsupportedMediaType, echo.Map{"error": "Unsupported attachment type"})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": message})
+}
+
+// attachmentParams reads the task and attachment ids from the path.
+func attachmentParams(c echo.Context) (int, int, error) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, 0, err
+	}
+	attachmentID, err := strconv.Atoi(c.Param("attachment_id"))
+	if err != nil {
+		return 0, 0, err
+	}
+	return taskID, attachmentID, nil
+}
+
+// @Summary Get attachments
+// @Description Get attachments of the task
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} []domain.AttachmentResponse
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/{id}/attachments [get]
+func (s *AttachmentServer) GetAttachments(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	attachments, err := s.service.GetAttachments(c.Request().Context(), id)
+	if err != nil {
+		return attachmentError(c, err, "Failed to get attachments")
+	}
+
+	attachmentsR := make([]*domain.AttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		attachmentsR = append(attachmentsR, domain.AttachmentToAttachmentResponse(attachment))
+	}
+
+	return c.JSON(http.StatusOK, attachmentsR)
+}
+
+// @Summary Upload attachment
+// @Description Attach a file to the task. Size and content type, detected from the contents, are limited by the server configuration
+// @Tags Attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param file formData file true "File"
+// @Success 201 {object} domain.AttachmentResponse
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 413 {object} string
+// @Failure 415 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/{id}/attachments [post]
+func (s *AttachmentServer) UploadAttachment(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	// Without a read deadline the upload falls back to the server timeout.
+	_ = http.NewResponseController(c.Response()).SetReadDeadline(time.Now().Add(uploadTimeout))
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, s.maxSize+multipartOverhead)
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return attachmentError(c, domain.AttachmentTooLarge, "")
+		}
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	contents, err := file.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+	defer contents.Close()
+
+	created, err := s.service.UploadAttachment(c.Request().Context(), &domain.Attachment{TaskID: id, Name: file.Filename, Size: file.Size}, contents)
+	if err != nil {
+		return attachmentError(c, err, "Failed to upload attachment")
+	}
+
+	return c.JSON(http.StatusCreated, domain.AttachmentToAttachmentResponse(created))
+}
+
+// @Summary Download attachment
+// @Description Download the contents of an attachment. The ETag is the SHA-256 checksum
+// @Tags Attachments
+// @Produce octet-stream
+// @Param id path int true "Task ID"
+// @Param attachment_id path int true "Attachment ID"
+// @Success 200 {file} file
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/{id}/attachments/{attachment_id} [get]
+func (s *AttachmentServer) DownloadAttachment(c echo.Context) error {
+	taskID, attachmentID, err := attachmentParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	attachment, contents, err := s.service.OpenAttachment(c.Request().Context(), taskID, attachmentID)
+	if err != nil {
+		return attachmentError(c, err, "Failed to download attachment")
+	}
+	defer contents.Close()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentDisposition, disposition)
+	header.Set(echo.HeaderContentLength, strconv.FormatInt(attachment.Size, 10))
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+	header.Set("ETag", `"`+attachment.Checksum+`"`)
+
+	return c.Stream(http.StatusOK, attachment.ContentType, contents)
+}
+
+// @Summary Delete attachment
+// @Description Delete an attachment with its contents
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param attachment_id path int true "Attachment ID"
+// @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/{id}/attachments/{attachment_id} [delete]
+func (s *AttachmentServer) DeleteAttachment(c echo.Context) error {
+	taskID, attachmentID, err := attachmentParams(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.DeleteAttachment(c.Request().Context(), taskID, attachmentID); err != nil {
+		return attachmentError(c, err, "Failed to delete attachment")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
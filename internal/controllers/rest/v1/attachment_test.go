@@ -0,0 +1,95 @@
+package v1_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+func multipartFile(t *testing.T, name string, contents []byte) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	part, err := w.CreateFormFile("file", name)
+	require.NoError(t, err)
+	_, err = part.Write(contents)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return body, w.FormDataContentType()
+}
+
+func TestUploadAttachment(t *testing.T) {
+	mockService := mocks.NewAttachmentServiceInterface(t)
+	server := v1.NewAttachmentControllers(mockService, 1<<20)
+	e := echo.New()
+
+	body, contentType := multipartFile(t, "notes.txt", []byte("meeting notes"))
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/1/attachments", body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService.On("UploadAttachment", mock.Anything, &domain.Attachment{TaskID: 1, Name: "notes.txt", Size: 13}, mock.MatchedBy(func(r io.Reader) bool {
+		data, err := io.ReadAll(r)
+		return err == nil && string(data) == "meeting notes"
+	})).Return(&domain.Attachment{ID: 5, TaskID: 1, Name: "notes.txt", ContentType: "text/plain; charset=utf-8", Size: 13}, nil)
+
+	if assert.NoError(t, server.UploadAttachment(c)) {
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"id":5`)
+	}
+}
+
+func TestUploadAttachmentTooLarge(t *testing.T) {
+	mockService := mocks.NewAttachmentServiceInterface(t)
+	server := v1.NewAttachmentControllers(mockService, 16)
+	e := echo.New()
+
+	body, contentType := multipartFile(t, "big.bin", bytes.Repeat([]byte("x"), 128<<10))
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/1/attachments", body)
+	req.Header.Set(echo.HeaderContentType, contentType)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	if assert.NoError(t, server.UploadAttachment(c)) {
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	}
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	mockService := mocks.NewAttachmentServiceInterface(t)
+	server := v1.NewAttachmentControllers(mockService, 1<<20)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/1/attachments/5", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id", "attachment_id")
+	c.SetParamValues("1", "5")
+
+	attachment := &domain.Attachment{ID: 5, TaskID: 1, Name: "отчёт.pdf", ContentType: "application/pdf", Size: 8, Checksum: "abc123"}
+	mockService.On("OpenAttachment", mock.Anything, 1, 5).Return(attachment, io.NopCloser(strings.NewReader("%PDF-1.4")), nil)
+
+	if assert.NoError(t, server.DownloadAttachment(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/pdf", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `"abc123"`, rec.Header().Get("ETag"))
+		assert.Equal(t, "attachment; filename*=utf-8''%D0%BE%D1%82%D1%87%D1%91%D1%82.pdf", rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Equal(t, "%PDF-1.4", rec.Body.String())
+	}
+}
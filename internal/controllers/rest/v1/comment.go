@@ -0,0 +1,206 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wazwki/skillsrock/internal/controllers/rest"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service"
+)
+
+type CommentServer struct {
+	service service.CommentServiceInterface
+}
+
+func NewCommentControllers(s service.CommentServiceInterface) rest.CommentControllersInterface {
+	return &CommentServer{service: s}
+}
+
+func commentError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, domain.Unauthorized):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Unauthorized"})
+	case errors.Is(err, domain.Forbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Forbidden"})
+	case errors.Is(err, domain.TaskNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Task not found"})
+	case errors.Is(err, domain.ProjectNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found"})
+	case errors.Is(err, domain.CommentNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Comment not found"})
+	case errors.Is(err, domain.InvalidComment):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid comment"})
+	case errors.Is(err, domain.InvalidParentComment):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid parent comment"})
+	case errors.Is(err, domain.InvalidPage):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid page"})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": message})
+}
+
+// pageParam reads the optional limit and offset query parameters.
+func pageParam(c echo.Context) (domain.Page, error) {
+	var page domain.Page
+	var err error
+	if limit := c.QueryParam("limit"); limit != "" {
+		if page.Limit, err = strconv.Atoi(limit); err != nil {
+			return page, err
+		}
+	}
+	if offset := c.QueryParam("offset"); offset != "" {
+		if page.Offset, err = strconv.Atoi(offset); err != nil {
+			return page, err
+		}
+	}
+	return page, nil
+}
+
+// @Summary Get comments
+// @Description Get a page of top-level comments of the task, oldest first, with all of their replies. Bodies are markdown
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param limit query int false "Top-level comments per page, 20 by default, at most 100"
+// @Param offset query int false "Top-level comments to skip"
+// @Success 200 {object} domain.CommentPageResponse
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/{id}/comments [get]
+func (s *CommentServer) GetComments(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	page, err := pageParam(c)
+	if err != nil || !domain.ValidPage(&page) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid page"})
+	}
+
+	comments, total, err := s.service.GetComments(c.Request().Context(), id, page)
+	if err != nil {
+		return commentError(c, err, "Failed to get comments")
+	}
+
+	commentsR := make([]*domain.CommentResponse, 0, len(comments))
+	for _, comment := range comments {
+		commentsR = append(commentsR, domain.CommentToCommentResponse(comment))
+	}
+
+	return c.JSON(http.StatusOK, domain.CommentPageResponse{
+		Total:    total,
+		Limit:    page.Limit,
+		Offset:   page.Offset,
+		Comments: commentsR,
+	})
+}
+
+// @Summary Create comment
+// @Description Comment on the task, or reply to a comment with parent_id. Bodies are markdown
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param comment body domain.CommentRequest true "Comment"
+// @Success 201 {object} domain.CommentResponse
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/{id}/comments [post]
+func (s *CommentServer) CreateComment(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	var comment *domain.CommentRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&comment); err != nil || comment == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	dComment := domain.CommentFromCommentRequest(comment)
+	dComment.TaskID = id
+
+	created, err := s.service.CreateComment(c.Request().Context(), dComment)
+	if err != nil {
+		return commentError(c, err, "Failed to create comment")
+	}
+
+	return c.JSON(http.StatusCreated, domain.CommentToCommentResponse(created))
+}
+
+// @Summary Update comment
+// @Description Edit a comment, authors only
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param comment_id path int true "Comment ID"
+// @Param comment body domain.CommentRequest true "Comment"
+// @Success 200 {object} domain.CommentResponse
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/{id}/comments/{comment_id} [put]
+func (s *CommentServer) UpdateComment(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	commentID, err := strconv.Atoi(c.Param("comment_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	var comment *domain.CommentRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&comment); err != nil || comment == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	updated, err := s.service.UpdateComment(c.Request().Context(), &domain.Comment{ID: commentID, TaskID: id, Body: comment.Body})
+	if err != nil {
+		return commentError(c, err, "Failed to update comment")
+	}
+
+	return c.JSON(http.StatusOK, domain.CommentToCommentResponse(updated))
+}
+
+// @Summary Delete comment
+// @Description Delete a comment, authors only. A comment with replies stays in the thread with an empty body
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param comment_id path int true "Comment ID"
+// @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/{id}/comments/{comment_id} [delete]
+func (s *CommentServer) DeleteComment(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	commentID, err := strconv.Atoi(c.Param("comment_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.DeleteComment(c.Request().Context(), id, commentID); err != nil {
+		return commentError(c, err, "Failed to delete comment")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
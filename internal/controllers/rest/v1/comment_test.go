@@ -0,0 +1,90 @@
+package v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+func TestGetComments(t *testing.T) {
+	mockService := mocks.NewCommentServiceInterface(t)
+	server := v1.NewCommentControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/1/comments?limit=1&offset=1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	threads := domain.BuildCommentThreads([]*domain.Comment{
+		{ID: 2, TaskID: 1, UserID: 1, Body: "First", CreatedAt: created, UpdatedAt: created, Deleted: true},
+		{ID: 3, TaskID: 1, UserID: 2, ParentID: 2, Body: "Reply", CreatedAt: created, UpdatedAt: created.Add(time.Minute)},
+		{ID: 4, TaskID: 1, UserID: 1, ParentID: 3, Body: "Nested *reply*", CreatedAt: created, UpdatedAt: created},
+	})
+	mockService.On("GetComments", mock.Anything, 1, domain.Page{Limit: 1, Offset: 1}).Return(threads, 3, nil)
+
+	if assert.NoError(t, server.GetComments(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp domain.CommentPageResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, 3, resp.Total)
+		if assert.Len(t, resp.Comments, 1) {
+			root := resp.Comments[0]
+			assert.True(t, root.Deleted)
+			if assert.Len(t, root.Replies, 1) && assert.Len(t, root.Replies[0].Replies, 1) {
+				assert.True(t, root.Replies[0].Edited)
+				assert.Equal(t, "Nested *reply*", root.Replies[0].Replies[0].Body)
+			}
+		}
+	}
+}
+
+func TestGetCommentsInvalidPage(t *testing.T) {
+	mockService := mocks.NewCommentServiceInterface(t)
+	server := v1.NewCommentControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/1/comments?limit=500", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	if assert.NoError(t, server.GetComments(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestUpdateCommentNotAuthor(t *testing.T) {
+	mockService := mocks.NewCommentServiceInterface(t)
+	server := v1.NewCommentControllers(mockService)
+	e := echo.New()
+
+	jsonReq, _ := json.Marshal(domain.CommentRequest{Body: "Edited"})
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/tasks/1/comments/2", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id", "comment_id")
+	c.SetParamValues("1", "2")
+
+	mockService.On("UpdateComment", mock.Anything, &domain.Comment{ID: 2, TaskID: 1, Body: "Edited"}).Return(nil, domain.Forbidden)
+
+	if assert.NoError(t, server.UpdateComment(c)) {
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	}
+}
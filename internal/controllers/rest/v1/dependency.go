@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+// @Summary Get dependencies
+// @Description Get the tasks blocking a task
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} []domain.TaskResponse
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/{id}/dependencies [get]
+func (s *TaskServer) GetDependencies(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	blockers, err := s.service.GetBlockers(c.Request().Context(), id)
+	if err != nil {
+		return taskError(c, err, "Failed to get dependencies")
+	}
+
+	tasksR := make([]*domain.TaskResponse, 0, len(blockers))
+	for _, task := range blockers {
+		tasksR = append(tasksR, domain.TaskToTaskResponse(task))
+	}
+
+	return c.JSON(http.StatusOK, tasksR)
+}
+
+// @Summary Add dependency
+// @Description Mark a task as blocked by another task of the same project. Dependencies that would create a cycle are refused
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param dependency body domain.DependencyRequest true "Blocker"
+// @Success 201 {object} nil
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/{id}/dependencies [post]
+func (s *TaskServer) AddDependency(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	var req *domain.DependencyRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil || req == nil || req.BlockerID <= 0 {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.AddDependency(c.Request().Context(), domain.TaskDependency{TaskID: id, BlockerID: req.BlockerID}); err != nil {
+		return taskError(c, err, "Failed to add dependency")
+	}
+
+	return c.NoContent(http.StatusCreated)
+}
+
+// @Summary Remove dependency
+// @Description Remove a blocker from a task
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param blocker_id path int true "Blocker task ID"
+// @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/{id}/dependencies/{blocker_id} [delete]
+func (s *TaskServer) RemoveDependency(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	blockerID, err := strconv.Atoi(c.Param("blocker_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.RemoveDependency(c.Request().Context(), domain.TaskDependency{TaskID: id, BlockerID: blockerID}); err != nil {
+		return taskError(c, err, "Failed to remove dependency")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary Get critical path
+// @Description Get the longest chain of open tasks that transitively block a task, from the first one to work on to the task itself
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} domain.CriticalPathResponse
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/{id}/critical-path [get]
+func (s *TaskServer) GetCriticalPath(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	path, err := s.service.GetCriticalPath(c.Request().Context(), id)
+	if err != nil {
+		return taskError(c, err, "Failed to get critical path")
+	}
+
+	resp := &domain.CriticalPathResponse{Length: len(path), Tasks: make([]*domain.TaskResponse, 0, len(path))}
+	for _, task := range path {
+		resp.Tasks = append(resp.Tasks, domain.TaskToTaskResponse(task))
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,86 @@
+package v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+func TestAddDependencyCycle(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	jsonReq, _ := json.Marshal(domain.DependencyRequest{BlockerID: 2})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/1/dependencies", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService.On("AddDependency", mock.Anything, domain.TaskDependency{TaskID: 1, BlockerID: 2}).Return(domain.DependencyCycle)
+
+	if assert.NoError(t, server.AddDependency(c)) {
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	}
+}
+
+func TestUpdateBlockedTask(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	jsonReq, _ := json.Marshal(domain.TaskRequest{Title: "Task", Status: "in_progress", Priority: "low", Due_date: "2025-03-01 12:00:00"})
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/tasks/1", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService.On("UpdateTask", mock.Anything, mock.Anything, "").Return(nil, domain.TaskBlocked)
+
+	if assert.NoError(t, server.UpdateTask(c)) {
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	}
+}
+
+func TestGetCriticalPath(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/1/critical-path", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	path := []*domain.Task{
+		{ID: 4, Status: "in_progress"},
+		{ID: 3, Status: "pending"},
+		{ID: 1, Status: "pending"},
+	}
+	mockService.On("GetCriticalPath", mock.Anything, 1).Return(path, nil)
+
+	if assert.NoError(t, server.GetCriticalPath(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp domain.CriticalPathResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		if assert.Equal(t, 3, resp.Length) {
+			assert.Equal(t, []int{4, 3, 1}, []int{resp.Tasks[0].ID, resp.Tasks[1].ID, resp.Tasks[2].ID})
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+// @Summary Get task history
+// @Description Get a page of changes of the task, oldest first. Every event lists the changed fields with their old and new values. The history is kept after the task is deleted
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param limit query int false "Events per page, 20 by default, at most 100"
+// @Param offset query int false "Events to skip"
+// @Success 200 {object} domain.TaskHistoryResponse
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/{id}/history [get]
+func (s *TaskServer) GetTaskHistory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	page, err := pageParam(c)
+	if err != nil || !domain.ValidPage(&page) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid page"})
+	}
+
+	events, total, err := s.service.GetTaskHistory(c.Request().Context(), id, page)
+	if err != nil {
+		return taskError(c, err, "Failed to get task history")
+	}
+
+	eventsR := make([]*domain.TaskEventResponse, 0, len(events))
+	for _, event := range events {
+		eventsR = append(eventsR, domain.TaskEventToTaskEventResponse(event))
+	}
+
+	return c.JSON(http.StatusOK, domain.TaskHistoryResponse{
+		Total:  total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+		Events: eventsR,
+	})
+}
@@ -0,0 +1,87 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+func TestGetTaskHistory(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/1/history?limit=2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	events := []*domain.TaskEvent{
+		{ID: 1, TaskID: 1, ActorID: 1, Actor: "Alice", Action: "created", CreatedAt: created, Changes: []domain.FieldChange{
+			{Field: "title", Old: json.RawMessage(`null`), New: json.RawMessage(`"Task"`)},
+		}},
+		{ID: 2, TaskID: 1, Action: "updated", CreatedAt: created.Add(time.Hour), Changes: []domain.FieldChange{
+			{Field: "status", Old: json.RawMessage(`"new"`), New: json.RawMessage(`"done"`)},
+		}},
+	}
+	mockService.On("GetTaskHistory", mock.Anything, 1, domain.Page{Limit: 2}).Return(events, 5, nil)
+
+	if assert.NoError(t, server.GetTaskHistory(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp domain.TaskHistoryResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, 5, resp.Total)
+		if assert.Len(t, resp.Events, 2) {
+			assert.Equal(t, "Alice", resp.Events[0].Actor)
+			assert.Equal(t, 0, resp.Events[1].ActorID)
+			assert.Equal(t, "status", resp.Events[1].Changes[0].Field)
+			assert.JSONEq(t, `"done"`, string(resp.Events[1].Changes[0].New))
+		}
+	}
+}
+
+func TestGetTaskHistoryNotFound(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/1/history", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService.On("GetTaskHistory", mock.Anything, 1, domain.Page{Limit: domain.DefaultPageLimit}).Return(nil, 0, domain.TaskNotFound)
+
+	if assert.NoError(t, server.GetTaskHistory(c)) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestGetTaskHistoryInvalidPage(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/1/history?offset=-1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	if assert.NoError(t, server.GetTaskHistory(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
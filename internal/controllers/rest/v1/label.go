@@ -0,0 +1,165 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wazwki/skillsrock/internal/controllers/rest"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service"
+)
+
+type LabelServer struct {
+	service service.LabelServiceInterface
+}
+
+func NewLabelControllers(s service.LabelServiceInterface) rest.LabelControllersInterface {
+	return &LabelServer{service: s}
+}
+
+func labelError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, domain.Unauthorized):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Unauthorized"})
+	case errors.Is(err, domain.Forbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Forbidden"})
+	case errors.Is(err, domain.ProjectNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found"})
+	case errors.Is(err, domain.LabelNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Label not found"})
+	case errors.Is(err, domain.InvalidLabel):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid label"})
+	case errors.Is(err, domain.LabelAlreadyExists):
+		return c.JSON(http.StatusConflict, echo.Map{"error": "Label already exists"})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": message})
+}
+
+// @Summary Get labels
+// @Description Get labels of a project, or personal labels without project_id
+// @Tags Labels
+// @Accept json
+// @Produce json
+// @Param project_id query int false "Project ID"
+// @Success 200 {object} []domain.LabelResponse
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/labels [get]
+func (s *LabelServer) GetLabels(c echo.Context) error {
+	projectID, err := projectIDParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	labels, err := s.service.GetLabels(c.Request().Context(), projectID)
+	if err != nil {
+		return labelError(c, err, "Failed to get labels")
+	}
+
+	labelsR := make([]*domain.LabelResponse, 0, len(labels))
+	for _, label := range labels {
+		labelsR = append(labelsR, domain.LabelToLabelResponse(label))
+	}
+
+	return c.JSON(http.StatusOK, labelsR)
+}
+
+// @Summary Create label
+// @Description Create label with a #rrggbb colour, grey by default
+// @Tags Labels
+// @Accept json
+// @Produce json
+// @Param label body domain.LabelRequest true "Label"
+// @Param project_id query int false "Project ID"
+// @Success 201 {object} domain.LabelResponse
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/labels [post]
+func (s *LabelServer) CreateLabel(c echo.Context) error {
+	projectID, err := projectIDParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	var label *domain.LabelRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&label); err != nil || label == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	dLabel := domain.LabelFromLabelRequest(label)
+	dLabel.ProjectID = projectID
+
+	created, err := s.service.CreateLabel(c.Request().Context(), dLabel)
+	if err != nil {
+		return labelError(c, err, "Failed to create label")
+	}
+
+	return c.JSON(http.StatusCreated, domain.LabelToLabelResponse(created))
+}
+
+// @Summary Update label
+// @Description Rename or recolour label
+// @Tags Labels
+// @Accept json
+// @Produce json
+// @Param id path int true "Label ID"
+// @Param label body domain.LabelRequest true "Label"
+// @Success 200 {object} domain.LabelResponse
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/labels/{id} [put]
+func (s *LabelServer) UpdateLabel(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	var label *domain.LabelRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&label); err != nil || label == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	dLabel := domain.LabelFromLabelRequest(label)
+	dLabel.ID = id
+
+	updated, err := s.service.UpdateLabel(c.Request().Context(), dLabel)
+	if err != nil {
+		return labelError(c, err, "Failed to update label")
+	}
+
+	return c.JSON(http.StatusOK, domain.LabelToLabelResponse(updated))
+}
+
+// @Summary Delete label
+// @Description Delete label and remove it from all tasks
+// @Tags Labels
+// @Accept json
+// @Produce json
+// @Param id path int true "Label ID"
+// @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/labels/{id} [delete]
+func (s *LabelServer) DeleteLabel(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.DeleteLabel(c.Request().Context(), id); err != nil {
+		return labelError(c, err, "Failed to delete label")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,81 @@
+package v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+func TestCreateLabelConflict(t *testing.T) {
+	mockService := mocks.NewLabelServiceInterface(t)
+	server := v1.NewLabelControllers(mockService)
+	e := echo.New()
+
+	jsonReq, _ := json.Marshal(domain.LabelRequest{Name: "bug", Color: "#ff0000"})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/labels?project_id=2", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("CreateLabel", mock.Anything, &domain.Label{ProjectID: 2, Name: "bug", Color: "#ff0000"}).Return(nil, domain.LabelAlreadyExists)
+
+	if assert.NoError(t, server.CreateLabel(c)) {
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	}
+}
+
+func TestGetTasksByLabels(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks?labels=bug,%20ui,bug&label_match=all", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	tasks := []*domain.Task{{ID: 1, Labels: []*domain.Label{{ID: 3, Name: "bug", Color: "#ff0000"}, {ID: 4, Name: "ui", Color: "#9e9e9e"}}}}
+	mockService.On("GetTasks", mock.Anything, domain.TaskFilter{Labels: []string{"bug", "ui"}, LabelMatch: domain.LabelMatchAll}).Return(tasks, nil)
+
+	if assert.NoError(t, server.GetTasks(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp []domain.TaskResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		if assert.Len(t, resp, 1) && assert.Len(t, resp[0].Labels, 2) {
+			assert.Equal(t, "bug", resp[0].Labels[0].Name)
+			assert.Equal(t, "#ff0000", resp[0].Labels[0].Color)
+		}
+	}
+}
+
+func TestImportExportedLabels(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	exported := domain.TaskToTaskResponse(&domain.Task{ID: 1, Title: "Task", Status: "pending", Priority: "low", Labels: []*domain.Label{{ID: 3, Name: "bug", Color: "#ff0000"}}})
+	jsonReq, _ := json.Marshal([]*domain.TaskResponse{exported})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/import", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("ImportTasks", mock.Anything, 0, mock.MatchedBy(func(tasks []*domain.Task) bool {
+		return len(tasks) == 1 && len(tasks[0].Labels) == 1 && *tasks[0].Labels[0] == domain.Label{Name: "bug", Color: "#ff0000"}
+	})).Return(nil)
+
+	if assert.NoError(t, server.ImportTasks(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
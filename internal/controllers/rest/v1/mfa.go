@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wazwki/skillsrock/internal/controllers/rest"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service"
+)
+
+type MFAServer struct {
+	service service.MFAServiceInterface
+}
+
+func NewMFAControllers(s service.MFAServiceInterface) rest.MFAControllersInterface {
+	return &MFAServer{service: s}
+}
+
+func mfaError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, domain.Unauthorized):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Unauthorized"})
+	case errors.Is(err, domain.Forbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Forbidden"})
+	case errors.Is(err, domain.MFAAlreadyEnabled):
+		return c.JSON(http.StatusConflict, echo.Map{"error": "MFA already enabled"})
+	case errors.Is(err, domain.MFANotEnrolled):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "MFA not enrolled"})
+	case errors.Is(err, domain.InvalidMFACode):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid code"})
+	case errors.Is(err, domain.InvalidPassword):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid password"})
+	case errors.Is(err, domain.RecentLoginRequired):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Recent login required"})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": message})
+}
+
+// @Summary Enrol TOTP
+// @Description Generate a TOTP secret and its otpauth provisioning URI for a QR code. The secret becomes active once confirmed
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Success 200 {object} domain.TOTPEnrolmentResponse
+// @Failure 401 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/users/me/mfa/totp [post]
+func (s *MFAServer) EnrolTOTP(c echo.Context) error {
+	enrolment, err := s.service.EnrolTOTP(c.Request().Context())
+	if err != nil {
+		return mfaError(c, err, "Failed to enrol TOTP")
+	}
+
+	return c.JSON(http.StatusOK, domain.TOTPEnrolmentToResponse(enrolment))
+}
+
+// @Summary Confirm TOTP
+// @Description Enable TOTP with a code from the authenticator app. Returns recovery codes, shown only once
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Param code body domain.MFACodeRequest true "Code"
+// @Success 200 {object} domain.RecoveryCodesResponse
+// @Failure 400 {object} string
+// @Failure 401 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/users/me/mfa/totp/confirm [post]
+func (s *MFAServer) ConfirmTOTP(c echo.Context) error {
+	var req *domain.MFACodeRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil || req == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	codes, err := s.service.ConfirmTOTP(c.Request().Context(), req.Code)
+	if err != nil {
+		return mfaError(c, err, "Failed to confirm TOTP")
+	}
+
+	return c.JSON(http.StatusOK, domain.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// @Summary Disable TOTP
+// @Description Disable two-factor authentication, requires the password. Accounts without a password confirm with a login made in the last five minutes
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Param password body domain.MFADisableRequest true "Password"
+// @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 401 {object} string
+// @Failure 403 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/users/me/mfa/totp [delete]
+func (s *MFAServer) DisableTOTP(c echo.Context) error {
+	var req *domain.MFADisableRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil || req == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.DisableTOTP(c.Request().Context(), req.Password); err != nil {
+		return mfaError(c, err, "Failed to disable TOTP")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
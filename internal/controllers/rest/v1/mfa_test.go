@@ -0,0 +1,78 @@
+package v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+func TestEnrolTOTP(t *testing.T) {
+	mockService := mocks.NewMFAServiceInterface(t)
+	server := v1.NewMFAControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/mfa/totp", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("EnrolTOTP", mock.Anything).Return(&domain.TOTPEnrolment{
+		Secret:          "SECRET",
+		ProvisioningURI: "otpauth://totp/skillsrock:john?secret=SECRET",
+	}, nil)
+
+	if assert.NoError(t, server.EnrolTOTP(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp domain.TOTPEnrolmentResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "SECRET", resp.Secret)
+	}
+}
+
+func TestEnrolTOTPAlreadyEnabled(t *testing.T) {
+	mockService := mocks.NewMFAServiceInterface(t)
+	server := v1.NewMFAControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/mfa/totp", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("EnrolTOTP", mock.Anything).Return(nil, domain.MFAAlreadyEnabled)
+
+	if assert.NoError(t, server.EnrolTOTP(c)) {
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	}
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	mockService := mocks.NewMFAServiceInterface(t)
+	server := v1.NewMFAControllers(mockService)
+	e := echo.New()
+
+	jsonReq, _ := json.Marshal(domain.MFACodeRequest{Code: "123456"})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/mfa/totp/confirm", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("ConfirmTOTP", mock.Anything, "123456").Return([]string{"abcde-12345"}, nil)
+
+	if assert.NoError(t, server.ConfirmTOTP(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp domain.RecoveryCodesResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, []string{"abcde-12345"}, resp.RecoveryCodes)
+	}
+}
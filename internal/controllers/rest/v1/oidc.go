@@ -0,0 +1,133 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+const (
+	oidcCookieName = "OIDC-Login"
+	oidcCookiePath = "/api/v1/auth/oidc"
+	// oidcCookieMaxAge bounds how long the user may take at the provider.
+	oidcCookieMaxAge = 600
+)
+
+// @Summary Start SSO login
+// @Description Redirect to the OpenID Connect provider. The provider redirects back to /api/v1/auth/oidc/callback
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Success 302 {object} nil
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/auth/oidc/login [get]
+func (s *UserServer) OIDCLogin(c echo.Context) error {
+	login, err := s.service.StartOIDCLogin(c.Request().Context())
+	if err != nil {
+		return loginError(c, err)
+	}
+
+	setOIDCCookie(c, login)
+
+	return c.Redirect(http.StatusFound, login.URL)
+}
+
+// @Summary Link SSO account
+// @Description Start linking the account at the OpenID Connect provider to the current user. Open the returned URL in the browser, the provider redirects back to /api/v1/auth/oidc/callback which then signs the user in
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Success 200 {object} domain.OIDCLinkResponse
+// @Failure 401 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/users/me/identities/oidc [post]
+func (s *UserServer) LinkOIDC(c echo.Context) error {
+	login, err := s.service.StartOIDCLink(c.Request().Context())
+	if err != nil {
+		if errors.Is(err, domain.OIDCDisabled) {
+			return c.JSON(http.StatusNotFound, echo.Map{"error": "OIDC login is not configured"})
+		}
+		return profileError(c, err, "Failed to link account")
+	}
+
+	setOIDCCookie(c, login)
+
+	return c.JSON(http.StatusOK, domain.OIDCLinkResponse{URL: login.URL})
+}
+
+// setOIDCCookie keeps a started request for the callback. State, nonce, the
+// PKCE verifier and the link token are base64url, so dots separate them;
+// the link token is itself three dot-separated parts.
+func setOIDCCookie(c echo.Context, login *domain.OIDCLogin) {
+	parts := []string{login.State, login.Nonce, login.Verifier}
+	if login.LinkToken != "" {
+		parts = append(parts, login.LinkToken)
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     oidcCookieName,
+		Value:    strings.Join(parts, "."),
+		Path:     oidcCookiePath,
+		MaxAge:   oidcCookieMaxAge,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// @Summary Complete SSO login
+// @Description Exchange the authorization code from the OpenID Connect provider for access and refresh tokens. Unknown users are created, or linked to the user who started linking. Users with two-factor authentication get an MFA token instead (domain.MFAChallengeResponse)
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param code query string true "Authorization code"
+// @Param state query string true "State"
+// @Success 200 {object} domain.TokensResponse
+// @Failure 400 {object} string
+// @Failure 401 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 429 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/auth/oidc/callback [get]
+func (s *UserServer) OIDCCallback(c echo.Context) error {
+	c.SetCookie(&http.Cookie{Name: oidcCookieName, Path: oidcCookiePath, MaxAge: -1, HttpOnly: true})
+
+	if c.QueryParam("error") != "" {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "SSO login failed"})
+	}
+
+	code, state := c.QueryParam("code"), c.QueryParam("state")
+	cookie, err := c.Cookie(oidcCookieName)
+	if err != nil || code == "" || state == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	parts := strings.SplitN(cookie.Value, ".", 4)
+	if len(parts) < 3 {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+	login := &domain.OIDCLogin{State: parts[0], Nonce: parts[1], Verifier: parts[2]}
+	if len(parts) == 4 {
+		login.LinkToken = parts[3]
+	}
+
+	tokens, err := s.service.CompleteOIDCLogin(c.Request().Context(), login, code, state)
+	if err != nil {
+		return loginError(c, err)
+	}
+
+	if tokens.MFAToken != "" {
+		return c.JSON(http.StatusOK, domain.TokensToMFAChallengeResponse(tokens))
+	}
+
+	setAuthCookies(c, tokens)
+
+	return c.JSON(http.StatusOK, domain.TokensToTokensResponse(tokens))
+}
@@ -0,0 +1,126 @@
+package v1_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+func TestOIDCLogin(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/login", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("StartOIDCLogin", mock.Anything).Return(&domain.OIDCLogin{
+		URL: "https://idp.example.com/authorize", State: "state", Nonce: "nonce", Verifier: "verifier",
+	}, nil)
+
+	if assert.NoError(t, server.OIDCLogin(c)) {
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, "https://idp.example.com/authorize", rec.Header().Get(echo.HeaderLocation))
+		assert.Contains(t, rec.Header().Get("Set-Cookie"), "OIDC-Login=state.nonce.verifier")
+	}
+}
+
+func TestOIDCLoginDisabled(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/login", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("StartOIDCLogin", mock.Anything).Return(nil, domain.OIDCDisabled)
+
+	if assert.NoError(t, server.OIDCLogin(c)) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestOIDCCallback(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/callback?code=code&state=state", nil)
+	req.AddCookie(&http.Cookie{Name: "OIDC-Login", Value: "state.nonce.verifier"})
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	login := &domain.OIDCLogin{State: "state", Nonce: "nonce", Verifier: "verifier"}
+	mockService.On("CompleteOIDCLogin", mock.Anything, login, "code", "state").Return(&domain.Tokens{
+		AccessToken:      "access",
+		RefreshToken:     "refresh",
+		AccessExpiresAt:  time.Now().Add(time.Hour),
+		RefreshExpiresAt: time.Now().Add(24 * time.Hour),
+	}, nil)
+
+	if assert.NoError(t, server.OIDCCallback(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestOIDCCallbackWithoutCookie(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/callback?code=code&state=state", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if assert.NoError(t, server.OIDCCallback(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestLinkOIDC(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/identities/oidc", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("StartOIDCLink", mock.Anything).Return(&domain.OIDCLogin{
+		URL: "https://idp.example.com/authorize", State: "state", Nonce: "nonce", Verifier: "verifier", LinkToken: "a.b.c",
+	}, nil)
+
+	if assert.NoError(t, server.LinkOIDC(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "https://idp.example.com/authorize")
+		assert.Contains(t, rec.Header().Get("Set-Cookie"), "OIDC-Login=state.nonce.verifier.a.b.c")
+	}
+}
+
+func TestOIDCCallbackLink(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/callback?code=code&state=state", nil)
+	req.AddCookie(&http.Cookie{Name: "OIDC-Login", Value: "state.nonce.verifier.a.b.c"})
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	login := &domain.OIDCLogin{State: "state", Nonce: "nonce", Verifier: "verifier", LinkToken: "a.b.c"}
+	mockService.On("CompleteOIDCLogin", mock.Anything, login, "code", "state").Return(nil, domain.IdentityInUse)
+
+	if assert.NoError(t, server.OIDCCallback(c)) {
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	}
+}
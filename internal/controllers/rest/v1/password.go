@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wazwki/skillsrock/internal/controllers/rest"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service"
+)
+
+type PasswordServer struct {
+	service service.PasswordServiceInterface
+}
+
+func NewPasswordControllers(s service.PasswordServiceInterface) rest.PasswordControllersInterface {
+	return &PasswordServer{service: s}
+}
+
+func passwordError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, domain.Unauthorized):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Unauthorized"})
+	case errors.Is(err, domain.Forbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Forbidden"})
+	case errors.Is(err, domain.WeakPassword):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Password is too short"})
+	case errors.Is(err, domain.InvalidPassword):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid current password"})
+	case errors.Is(err, domain.InvalidToken):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid token"})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": message})
+}
+
+// @Summary Change password
+// @Description Change the password of the current user. All sessions are ended
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param password body domain.PasswordChangeRequest true "Passwords"
+// @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 401 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/users/me/password [put]
+func (s *PasswordServer) ChangePassword(c echo.Context) error {
+	var req *domain.PasswordChangeRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil || req == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.ChangePassword(c.Request().Context(), req.CurrentPassword, req.NewPassword); err != nil {
+		return passwordError(c, err, "Failed to change password")
+	}
+
+	clearAuthCookies(c)
+
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary Request password reset
+// @Description Send a single-use password reset token to the user. The response does not tell whether the user exists
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param user body domain.PasswordForgotRequest true "User"
+// @Success 202 {object} nil
+// @Failure 400 {object} string
+// @Failure 429 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/auth/password/forgot [post]
+func (s *PasswordServer) ForgotPassword(c echo.Context) error {
+	var req *domain.PasswordForgotRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil || req == nil || req.Name == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.RequestPasswordReset(c.Request().Context(), req.Name); err != nil {
+		return passwordError(c, err, "Failed to request password reset")
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
+
+// @Summary Reset password
+// @Description Set a new password with a reset token. All sessions of the user are ended
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param reset body domain.PasswordResetRequest true "Reset"
+// @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 429 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/auth/password/reset [post]
+func (s *PasswordServer) ResetPassword(c echo.Context) error {
+	var req *domain.PasswordResetRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil || req == nil || req.Token == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.ResetPassword(c.Request().Context(), req.Token, req.NewPassword); err != nil {
+		return passwordError(c, err, "Failed to reset password")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
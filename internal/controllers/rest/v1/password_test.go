@@ -0,0 +1,98 @@
+package v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+func TestChangePassword(t *testing.T) {
+	mockService := mocks.NewPasswordServiceInterface(t)
+	server := v1.NewPasswordControllers(mockService)
+	e := echo.New()
+
+	jsonReq, _ := json.Marshal(domain.PasswordChangeRequest{CurrentPassword: "password", NewPassword: "new-password"})
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/me/password", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("ChangePassword", mock.Anything, "password", "new-password").Return(nil)
+
+	if assert.NoError(t, server.ChangePassword(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		cookies := rec.Result().Cookies()
+		if assert.Len(t, cookies, 2) {
+			assert.Equal(t, -1, cookies[0].MaxAge)
+		}
+	}
+}
+
+func TestChangePasswordWrongCurrent(t *testing.T) {
+	mockService := mocks.NewPasswordServiceInterface(t)
+	server := v1.NewPasswordControllers(mockService)
+	e := echo.New()
+
+	jsonReq, _ := json.Marshal(domain.PasswordChangeRequest{CurrentPassword: "wrong", NewPassword: "new-password"})
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/me/password", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("ChangePassword", mock.Anything, "wrong", "new-password").Return(domain.InvalidPassword)
+
+	if assert.NoError(t, server.ChangePassword(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Empty(t, rec.Result().Cookies())
+	}
+}
+
+func TestForgotPassword(t *testing.T) {
+	mockService := mocks.NewPasswordServiceInterface(t)
+	server := v1.NewPasswordControllers(mockService)
+	e := echo.New()
+
+	jsonReq, _ := json.Marshal(domain.PasswordForgotRequest{Name: "nobody"})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/password/forgot", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("RequestPasswordReset", mock.Anything, "nobody").Return(nil)
+
+	if assert.NoError(t, server.ForgotPassword(c)) {
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+	}
+}
+
+func TestResetPasswordInvalidToken(t *testing.T) {
+	mockService := mocks.NewPasswordServiceInterface(t)
+	server := v1.NewPasswordControllers(mockService)
+	e := echo.New()
+
+	jsonReq, _ := json.Marshal(domain.PasswordResetRequest{Token: "used", NewPassword: "new-password"})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/password/reset", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("ResetPassword", mock.Anything, "used", "new-password").Return(domain.InvalidToken)
+
+	if assert.NoError(t, server.ResetPassword(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
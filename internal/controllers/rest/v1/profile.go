@@ -0,0 +1,98 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+func profileError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, domain.Unauthorized):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Unauthorized"})
+	case errors.Is(err, domain.Forbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Forbidden"})
+	case errors.Is(err, domain.UserNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "User not found"})
+	case errors.Is(err, domain.InvalidProfile):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid profile"})
+	case errors.Is(err, domain.InvalidPassword):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid password"})
+	case errors.Is(err, domain.RecentLoginRequired):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Recent login required"})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": message})
+}
+
+// @Summary Get profile
+// @Description Get the profile of the current user
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Success 200 {object} domain.ProfileResponse
+// @Failure 401 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/users/me [get]
+func (s *UserServer) GetMe(c echo.Context) error {
+	user, err := s.service.GetProfile(c.Request().Context())
+	if err != nil {
+		return profileError(c, err, "Failed to get profile")
+	}
+
+	return c.JSON(http.StatusOK, domain.UserToProfileResponse(user))
+}
+
+// @Summary Update profile
+// @Description Update display name, email, IANA timezone and locale of the current user
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param profile body domain.ProfileRequest true "Profile"
+// @Success 200 {object} domain.ProfileResponse
+// @Failure 400 {object} string
+// @Failure 401 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/users/me [put]
+func (s *UserServer) UpdateMe(c echo.Context) error {
+	var profile *domain.ProfileRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&profile); err != nil || profile == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	updated, err := s.service.UpdateProfile(c.Request().Context(), domain.ProfileFromProfileRequest(profile))
+	if err != nil {
+		return profileError(c, err, "Failed to update profile")
+	}
+
+	return c.JSON(http.StatusOK, domain.UserToProfileResponse(updated))
+}
+
+// @Summary Delete account
+// @Description Delete the current user with their personal tasks and API keys. Owned projects pass to another owner if there is one and are deleted otherwise. Accounts without a password confirm with a login made in the last five minutes
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param password body domain.DeleteAccountRequest true "Password"
+// @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 401 {object} string
+// @Failure 403 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/users/me [delete]
+func (s *UserServer) DeleteMe(c echo.Context) error {
+	var req *domain.DeleteAccountRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil || req == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.DeleteAccount(c.Request().Context(), req.Password); err != nil {
+		return profileError(c, err, "Failed to delete account")
+	}
+
+	clearAuthCookies(c)
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,93 @@
+package v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+func TestGetMe(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("GetProfile", mock.Anything).Return(&domain.User{ID: 1, Name: "john", Timezone: "UTC", Locale: "en", CreatedAt: time.Now()}, nil)
+
+	if assert.NoError(t, server.GetMe(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp domain.ProfileResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "john", resp.Name)
+	}
+}
+
+func TestUpdateMeInvalidProfile(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	profileReq := domain.ProfileRequest{Timezone: "Mars/Olympus", Locale: "en"}
+	jsonReq, _ := json.Marshal(profileReq)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/me", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("UpdateProfile", mock.Anything, mock.Anything).Return(nil, domain.InvalidProfile)
+
+	if assert.NoError(t, server.UpdateMe(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestDeleteMe(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	jsonReq, _ := json.Marshal(domain.DeleteAccountRequest{Password: "password"})
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/me", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("DeleteAccount", mock.Anything, "password").Return(nil)
+
+	if assert.NoError(t, server.DeleteMe(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestDeleteMeRecentLoginRequired(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/me", strings.NewReader(`{}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("DeleteAccount", mock.Anything, "").Return(domain.RecentLoginRequired)
+
+	if assert.NoError(t, server.DeleteMe(c)) {
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	}
+}
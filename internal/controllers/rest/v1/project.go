@@ -0,0 +1,306 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wazwki/skillsrock/internal/controllers/rest"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service"
+)
+
+type ProjectServer struct {
+	service service.ProjectServiceInterface
+}
+
+func NewProjectControllers(s service.ProjectServiceInterface) rest.ProjectControllersInterface {
+	return &ProjectServer{service: s}
+}
+
+func projectError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, domain.Unauthorized):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Unauthorized"})
+	case errors.Is(err, domain.Forbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Forbidden"})
+	case errors.Is(err, domain.ProjectNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found"})
+	case errors.Is(err, domain.ProjectMemberNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project member not found"})
+	case errors.Is(err, domain.UserNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "User not found"})
+	case errors.Is(err, domain.InvalidProjectRole):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid project role"})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": message})
+}
+
+// @Summary Get projects
+// @Description Get projects the user is a member of
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Success 200 {object} []domain.ProjectResponse
+// @Failure 500 {object} string
+// @Router /api/v1/projects [get]
+func (s *ProjectServer) GetProjects(c echo.Context) error {
+	projects, err := s.service.GetProjects(c.Request().Context())
+	if err != nil {
+		return projectError(c, err, "Failed to get projects")
+	}
+
+	projectsR := make([]*domain.ProjectResponse, 0, len(projects))
+	for _, project := range projects {
+		projectsR = append(projectsR, domain.ProjectToProjectResponse(project))
+	}
+
+	return c.JSON(http.StatusOK, projectsR)
+}
+
+// @Summary Create project
+// @Description Create project owned by the user
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param project body domain.ProjectRequest true "Project"
+// @Success 201 {object} domain.ProjectResponse
+// @Failure 400 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/projects [post]
+func (s *ProjectServer) CreateProject(c echo.Context) error {
+	var project *domain.ProjectRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&project); err != nil || project == nil || project.Name == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	created, err := s.service.CreateProject(c.Request().Context(), domain.ProjectFromProjectRequest(project))
+	if err != nil {
+		return projectError(c, err, "Failed to create project")
+	}
+
+	return c.JSON(http.StatusCreated, domain.ProjectToProjectResponse(created))
+}
+
+// @Summary Get project
+// @Description Get project
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {object} domain.ProjectResponse
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/projects/{id} [get]
+func (s *ProjectServer) GetProject(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	project, err := s.service.GetProject(c.Request().Context(), id)
+	if err != nil {
+		return projectError(c, err, "Failed to get project")
+	}
+
+	return c.JSON(http.StatusOK, domain.ProjectToProjectResponse(project))
+}
+
+// @Summary Update project
+// @Description Update project, owners only
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param project body domain.ProjectRequest true "Project"
+// @Success 200 {object} domain.ProjectResponse
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/projects/{id} [put]
+func (s *ProjectServer) UpdateProject(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	var project *domain.ProjectRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&project); err != nil || project == nil || project.Name == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	dProject := domain.ProjectFromProjectRequest(project)
+	dProject.ID = id
+
+	updated, err := s.service.UpdateProject(c.Request().Context(), dProject)
+	if err != nil {
+		return projectError(c, err, "Failed to update project")
+	}
+
+	return c.JSON(http.StatusOK, domain.ProjectToProjectResponse(updated))
+}
+
+// @Summary Delete project
+// @Description Delete project with all its tasks, owners only
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/projects/{id} [delete]
+func (s *ProjectServer) DeleteProject(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.DeleteProject(c.Request().Context(), id); err != nil {
+		return projectError(c, err, "Failed to delete project")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary Get project members
+// @Description Get project members
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Success 200 {object} []domain.ProjectMemberResponse
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/projects/{id}/members [get]
+func (s *ProjectServer) GetMembers(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	members, err := s.service.GetMembers(c.Request().Context(), id)
+	if err != nil {
+		return projectError(c, err, "Failed to get project members")
+	}
+
+	membersR := make([]*domain.ProjectMemberResponse, 0, len(members))
+	for _, member := range members {
+		membersR = append(membersR, domain.ProjectMemberToResponse(member))
+	}
+
+	return c.JSON(http.StatusOK, membersR)
+}
+
+// @Summary Add project member
+// @Description Add a user to the project with a role (owner, editor, viewer), owners only
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param member body domain.ProjectMemberRequest true "Member"
+// @Success 201 {object} nil
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/projects/{id}/members [post]
+func (s *ProjectServer) AddMember(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	var member *domain.ProjectMemberRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&member); err != nil || member == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	dMember := domain.ProjectMemberFromRequest(member)
+	dMember.ProjectID = id
+
+	if err := s.service.SetMember(c.Request().Context(), dMember); err != nil {
+		return projectError(c, err, "Failed to add project member")
+	}
+
+	return c.NoContent(http.StatusCreated)
+}
+
+// @Summary Update project member
+// @Description Change the role of a project member, owners only
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param user_id path int true "User ID"
+// @Param member body domain.ProjectMemberRequest true "Member"
+// @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/projects/{id}/members/{user_id} [put]
+func (s *ProjectServer) UpdateMember(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	var member *domain.ProjectMemberRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&member); err != nil || member == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	dMember := domain.ProjectMemberFromRequest(member)
+	dMember.ProjectID = id
+	dMember.UserID = userID
+
+	if err := s.service.SetMember(c.Request().Context(), dMember); err != nil {
+		return projectError(c, err, "Failed to update project member")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary Remove project member
+// @Description Remove a member from the project, owners only; members may remove themselves
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param id path int true "Project ID"
+// @Param user_id path int true "User ID"
+// @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/projects/{id}/members/{user_id} [delete]
+func (s *ProjectServer) RemoveMember(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.RemoveMember(c.Request().Context(), id, userID); err != nil {
+		return projectError(c, err, "Failed to remove project member")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
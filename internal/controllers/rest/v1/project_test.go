@@ -0,0 +1,76 @@
+package v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+func TestCreateProject(t *testing.T) {
+	mockService := mocks.NewProjectServiceInterface(t)
+	server := v1.NewProjectControllers(mockService)
+	e := echo.New()
+
+	projectReq := domain.ProjectRequest{Name: "Project 1", Description: "Description 1"}
+	jsonReq, _ := json.Marshal(projectReq)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/projects", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("CreateProject", mock.Anything, mock.Anything).Return(&domain.Project{ID: 1, Name: "Project 1"}, nil)
+
+	if assert.NoError(t, server.CreateProject(c)) {
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	}
+}
+
+func TestGetProjectNotMember(t *testing.T) {
+	mockService := mocks.NewProjectServiceInterface(t)
+	server := v1.NewProjectControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/projects/1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService.On("GetProject", mock.Anything, 1).Return(nil, domain.ProjectNotFound)
+
+	if assert.NoError(t, server.GetProject(c)) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestAddMemberForbidden(t *testing.T) {
+	mockService := mocks.NewProjectServiceInterface(t)
+	server := v1.NewProjectControllers(mockService)
+	e := echo.New()
+
+	memberReq := domain.ProjectMemberRequest{UserID: 2, Role: domain.ProjectRoleEditor}
+	jsonReq, _ := json.Marshal(memberReq)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/projects/1/members", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService.On("SetMember", mock.Anything, &domain.ProjectMember{ProjectID: 1, UserID: 2, Role: domain.ProjectRoleEditor}).Return(domain.Forbidden)
+
+	if assert.NoError(t, server.AddMember(c)) {
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	}
+}
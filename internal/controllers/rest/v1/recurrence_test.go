@@ -0,0 +1,82 @@
+package v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+func TestUpdateTaskFutureOccurrences(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	taskReq := domain.TaskRequest{Title: "Weekly report", Status: "pending", Priority: "medium", Due_date: "2025-03-03 10:00:00", Recurrence: "FREQ=WEEKLY;BYDAY=MO"}
+	body, _ := json.Marshal(taskReq)
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/tasks/1?occurrences=future", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	next := time.Date(2025, 3, 10, 10, 0, 0, 0, time.UTC)
+	mockService.On("UpdateTask", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+		return task.ID == 1 && task.Recurrence == "FREQ=WEEKLY;BYDAY=MO"
+	}), domain.OccurrencesFuture).Return(&domain.Task{ID: 1, Title: "Weekly report", SeriesID: 4, Recurrence: "FREQ=WEEKLY;BYDAY=MO", NextOccurrence: &next}, nil)
+
+	if assert.NoError(t, server.UpdateTask(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp domain.TaskResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, 4, resp.SeriesID)
+		assert.Equal(t, "2025-03-10 10:00:00", resp.NextOccurrence)
+	}
+}
+
+func TestCreateTaskInvalidRecurrence(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	taskReq := domain.TaskRequest{Title: "Task", Status: "pending", Priority: "low", Due_date: "2025-03-03 10:00:00", Recurrence: "FREQ=YEARLY"}
+	body, _ := json.Marshal(taskReq)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("CreateTask", mock.Anything, mock.Anything).Return("", domain.InvalidRecurrence)
+
+	if assert.NoError(t, server.CreateTask(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestUpdateTaskInvalidOccurrenceScope(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	body, _ := json.Marshal(domain.TaskRequest{Title: "Task", Status: "pending", Priority: "low"})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/tasks/1?occurrences=all", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService.On("UpdateTask", mock.Anything, mock.Anything, "all").Return(nil, domain.InvalidOccurrenceScope)
+
+	if assert.NoError(t, server.UpdateTask(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
@@ -0,0 +1,77 @@
+package v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+func TestCreateTaskWithReminders(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	taskReq := domain.TaskRequest{Title: "Report", Status: "pending", Priority: "high", Due_date: "2025-03-03 10:00:00", Reminders: []int{1440, 60}}
+	body, _ := json.Marshal(taskReq)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("CreateTask", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+		return assert.ObjectsAreEqual([]int{1440, 60}, task.Reminders)
+	})).Return("1", nil)
+
+	if assert.NoError(t, server.CreateTask(c)) {
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	}
+}
+
+func TestUpdateTaskInvalidReminder(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	body, _ := json.Marshal(domain.TaskRequest{Title: "Task", Status: "pending", Priority: "low", Reminders: []int{-5}})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/tasks/1", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService.On("UpdateTask", mock.Anything, mock.Anything, "").Return(nil, domain.InvalidReminder)
+
+	if assert.NoError(t, server.UpdateTask(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestGetTasksReminders(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("GetTasks", mock.Anything, mock.Anything).Return([]*domain.Task{{ID: 1, Title: "Report", Reminders: []int{1440, 60}}}, nil)
+
+	if assert.NoError(t, server.GetTasks(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp []domain.TaskResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		if assert.Len(t, resp, 1) {
+			assert.Equal(t, []int{1440, 60}, resp[0].Reminders)
+		}
+	}
+}
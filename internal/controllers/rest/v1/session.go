@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+func sessionError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, domain.Unauthorized):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Unauthorized"})
+	case errors.Is(err, domain.Forbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Forbidden"})
+	case errors.Is(err, domain.SessionNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Session not found"})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": message})
+}
+
+// @Summary Get sessions
+// @Description Get the devices the current user is logged in on, most recently used first
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Success 200 {object} []domain.SessionResponse
+// @Failure 401 {object} string
+// @Failure 403 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/users/me/sessions [get]
+func (s *UserServer) GetSessions(c echo.Context) error {
+	sessions, err := s.service.GetSessions(c.Request().Context())
+	if err != nil {
+		return sessionError(c, err, "Failed to get sessions")
+	}
+
+	sessionsR := make([]*domain.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		sessionsR = append(sessionsR, domain.SessionToSessionResponse(session))
+	}
+
+	return c.JSON(http.StatusOK, sessionsR)
+}
+
+// @Summary Revoke session
+// @Description Sign the current user out of a session, its refresh and access tokens stop working
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} nil
+// @Failure 401 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/users/me/sessions/{id} [delete]
+func (s *UserServer) RevokeSession(c echo.Context) error {
+	if err := s.service.RevokeSession(c.Request().Context(), c.Param("id")); err != nil {
+		return sessionError(c, err, "Failed to revoke session")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
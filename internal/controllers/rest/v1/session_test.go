@@ -0,0 +1,75 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+func TestGetSessions(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/sessions", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("GetSessions", mock.Anything).Return([]*domain.Session{
+		{ID: "family", IP: "127.0.0.1", UserAgent: "curl", CreatedAt: time.Now(), LastSeenAt: time.Now(), Current: true},
+	}, nil)
+
+	if assert.NoError(t, server.GetSessions(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp []domain.SessionResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		if assert.Len(t, resp, 1) {
+			assert.True(t, resp[0].Current)
+		}
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/me/sessions/family", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("family")
+
+	mockService.On("RevokeSession", mock.Anything, "family").Return(nil)
+
+	if assert.NoError(t, server.RevokeSession(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestRevokeSessionNotFound(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/me/sessions/other", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("other")
+
+	mockService.On("RevokeSession", mock.Anything, "other").Return(domain.SessionNotFound)
+
+	if assert.NoError(t, server.RevokeSession(c)) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+}
@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+// @Summary Get subtasks
+// @Description Get the direct subtasks of a task with their progress
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} []domain.TaskTreeResponse
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/{id}/subtasks [get]
+func (s *TaskServer) GetSubtasks(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	tree, err := s.service.GetTaskTree(c.Request().Context(), id)
+	if err != nil {
+		return taskError(c, err, "Failed to get subtasks")
+	}
+
+	subtasks := make([]*domain.TaskTreeResponse, 0, len(tree.Children))
+	for _, child := range tree.Children {
+		subtasks = append(subtasks, domain.TaskNodeToTreeResponse(child, 0))
+	}
+
+	return c.JSON(http.StatusOK, subtasks)
+}
+
+// @Summary Get task tree
+// @Description Get a task with all of its subtasks nested. Progress is the share of done leaf tasks, averaged level by level
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} domain.TaskTreeResponse
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/{id}/tree [get]
+func (s *TaskServer) GetTaskTree(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	tree, err := s.service.GetTaskTree(c.Request().Context(), id)
+	if err != nil {
+		return taskError(c, err, "Failed to get task tree")
+	}
+
+	return c.JSON(http.StatusOK, domain.TaskNodeToTreeResponse(tree, -1))
+}
+
+// @Summary Move task
+// @Description Make the task a subtask of another task in the same project, or a top-level task with parent_id 0
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param parent body domain.TaskParentRequest true "Parent"
+// @Success 200 {object} domain.TaskResponse
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/{id}/parent [put]
+func (s *TaskServer) SetParent(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	var req *domain.TaskParentRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil || req == nil || req.ParentID < 0 {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	task, err := s.service.SetParent(c.Request().Context(), id, req.ParentID)
+	if err != nil {
+		return taskError(c, err, "Failed to move task")
+	}
+
+	return c.JSON(http.StatusOK, domain.TaskToTaskResponse(task))
+}
@@ -0,0 +1,101 @@
+package v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+// newTaskTree is task 1 with a done subtask 2 and a subtask 3 that has two
+// subtasks of its own.
+func newTaskTree() *domain.TaskNode {
+	return &domain.TaskNode{
+		Task:     &domain.Task{ID: 1, Status: "in_progress"},
+		Progress: 100,
+		Children: []*domain.TaskNode{
+			{Task: &domain.Task{ID: 2, ParentID: 1, Status: "done"}, Progress: 100},
+			{Task: &domain.Task{ID: 3, ParentID: 1, Status: "pending"}, Progress: 100, Children: []*domain.TaskNode{
+				{Task: &domain.Task{ID: 4, ParentID: 3, Status: "done"}, Progress: 100},
+				{Task: &domain.Task{ID: 5, ParentID: 3, Status: "done"}, Progress: 100},
+			}},
+		},
+	}
+}
+
+func TestGetSubtasks(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/1/subtasks", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService.On("GetTaskTree", mock.Anything, 1).Return(newTaskTree(), nil)
+
+	if assert.NoError(t, server.GetSubtasks(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp []domain.TaskTreeResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		if assert.Len(t, resp, 2) {
+			assert.Equal(t, 100, resp[1].Progress)
+			assert.Empty(t, resp[1].Subtasks)
+		}
+	}
+}
+
+func TestGetTaskTree(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/1/tree", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService.On("GetTaskTree", mock.Anything, 1).Return(newTaskTree(), nil)
+
+	if assert.NoError(t, server.GetTaskTree(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp domain.TaskTreeResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, 100, resp.Progress)
+		if assert.Len(t, resp.Subtasks, 2) {
+			assert.Len(t, resp.Subtasks[1].Subtasks, 2)
+		}
+	}
+}
+
+func TestSetParentInvalid(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	jsonReq, _ := json.Marshal(domain.TaskParentRequest{ParentID: 3})
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/tasks/1/parent", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService.On("SetParent", mock.Anything, 1, 3).Return(nil, domain.InvalidParentTask)
+
+	if assert.NoError(t, server.SetParent(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
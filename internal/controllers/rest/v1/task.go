@@ -2,6 +2,7 @@ package v1
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -19,16 +20,72 @@ func NewTaskControllers(s service.TaskServiceInterface) rest.TaskControllersInte
 	return &TaskServer{service: s}
 }
 
+func taskError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, domain.Unauthorized):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Unauthorized"})
+	case errors.Is(err, domain.Forbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Forbidden"})
+	case errors.Is(err, domain.TaskNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Task not found"})
+	case errors.Is(err, domain.ProjectNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found"})
+	case errors.Is(err, domain.InvalidParentTask):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid parent task"})
+	case errors.Is(err, domain.InvalidChildrenMode):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid children mode"})
+	case errors.Is(err, domain.DependencyNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Dependency not found"})
+	case errors.Is(err, domain.DependencyCycle):
+		return c.JSON(http.StatusConflict, echo.Map{"error": "Dependency would create a cycle"})
+	case errors.Is(err, domain.TaskBlocked):
+		return c.JSON(http.StatusConflict, echo.Map{"error": "Task is blocked by open tasks"})
+	case errors.Is(err, domain.InvalidLabel):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid label"})
+	case errors.Is(err, domain.InvalidLabelMatch):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid label match"})
+	case errors.Is(err, domain.InvalidPage):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid page"})
+	case errors.Is(err, domain.InvalidRecurrence):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid recurrence"})
+	case errors.Is(err, domain.InvalidOccurrenceScope):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid occurrence scope"})
+	case errors.Is(err, domain.InvalidReminder):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid reminder"})
+	case errors.Is(err, domain.InvalidStatus):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid status"})
+	case errors.Is(err, domain.InvalidStatusCategory):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid status category"})
+	case errors.Is(err, domain.InvalidTransition):
+		return c.JSON(http.StatusConflict, echo.Map{"error": "Status transition not allowed"})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": message})
+}
+
+// projectIDParam reads the optional project_id query parameter, 0 meaning no project.
+func projectIDParam(c echo.Context) (int, error) {
+	projectID := c.QueryParam("project_id")
+	if projectID == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(projectID)
+}
+
 // @Summary Get tasks
 // @Description Get tasks
 // @Tags Tasks
 // @Accept json
 // @Produce json
-// @Param status query string false "Choose status: pending, in_progress, done"
+// @Param status query string false "Choose status of the workflow, e.g. pending, in_progress, done"
+// @Param category query string false "Choose status category: todo, in_progress, done"
 // @Param sort_by query string false "Choose sort by date: low, high"
 // @Param priority query string false "Choose priority: low, medium, high"
 // @Param name query string false "Choose name"
+// @Param labels query string false "Comma separated label names"
+// @Param label_match query string false "Match any (default) or all of the labels"
+// @Param project_id query int false "Project ID"
 // @Success 200 {object} []domain.TaskResponse
+// @Failure 400 {object} string
 // @Failure 500 {object} string
 // @Router /api/v1/tasks [get]
 func (s *TaskServer) GetTasks(c echo.Context) error {
@@ -36,15 +93,23 @@ func (s *TaskServer) GetTasks(c echo.Context) error {
 	sortBy := c.QueryParam("sort_by")
 	priority := c.QueryParam("priority")
 	name := c.QueryParam("name")
+	projectID, err := projectIDParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
 
 	tasks, err := s.service.GetTasks(c.Request().Context(), domain.TaskFilter{
-		Status:   status,
-		SortBy:   sortBy,
-		Priority: priority,
-		Name:     name,
+		ProjectID:  projectID,
+		Status:     status,
+		Category:   c.QueryParam("category"),
+		SortBy:     sortBy,
+		Priority:   priority,
+		Name:       name,
+		Labels:     domain.LabelNames(c.QueryParam("labels")),
+		LabelMatch: c.QueryParam("label_match"),
 	})
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to get tasks"})
+		return taskError(c, err, "Failed to get tasks")
 	}
 
 	var tasksR []*domain.TaskResponse
@@ -75,21 +140,24 @@ func (s *TaskServer) CreateTask(c echo.Context) error {
 
 	id, err := s.service.CreateTask(c.Request().Context(), domain.TaskFromTaskRequest(task))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to create task"})
+		return taskError(c, err, "Failed to create task")
 	}
 
 	return c.JSON(http.StatusCreated, id)
 }
 
 // @Summary Update task
-// @Description Update task
+// @Description Update task. Status changes have to follow a transition of the workflow of the task, and moving a task out of the todo category is refused while any of its blockers is open. For an occurrence of a recurring task occurrences=future also changes the series and its later open occurrences, an empty recurrence then ends the series
 // @Tags Tasks
 // @Accept json
 // @Produce json
 // @Param task body domain.TaskRequest true "Task"
 // @Param id path string true "ID"
+// @Param occurrences query string false "Choose occurrences of a recurring task: this (default), future"
 // @Success 200 {object} domain.TaskResponse
 // @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
 // @Failure 500 {object} string
 // @Router /api/v1/tasks/{id} [put]
 func (s *TaskServer) UpdateTask(c echo.Context) error {
@@ -109,29 +177,32 @@ func (s *TaskServer) UpdateTask(c echo.Context) error {
 	dTask := domain.TaskFromTaskRequest(task)
 	dTask.ID = id
 
-	uTask, err := s.service.UpdateTask(c.Request().Context(), dTask)
+	uTask, err := s.service.UpdateTask(c.Request().Context(), dTask, c.QueryParam("occurrences"))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to update task"})
+		return taskError(c, err, "Failed to update task")
 	}
 
 	return c.JSON(http.StatusOK, domain.TaskToTaskResponse(uTask))
 }
 
 // @Summary Delete task
-// @Description Delete task
+// @Description Move the task with its subtasks to the trash, or move the subtasks to the parent of the task first with children=reparent
 // @Tags Tasks
 // @Accept json
 // @Produce json
 // @Param id path string true "ID"
+// @Param children query string false "Choose subtask handling: cascade (default), reparent"
 // @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 404 {object} string
 // @Failure 500 {object} string
 // @Router /api/v1/tasks/{id} [delete]
 func (s *TaskServer) DeleteTask(c echo.Context) error {
 	id := c.Param("id")
 
-	err := s.service.DeleteTask(c.Request().Context(), id)
+	err := s.service.DeleteTask(c.Request().Context(), id, c.QueryParam("children"))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to delete task"})
+		return taskError(c, err, "Failed to delete task")
 	}
 
 	return nil
@@ -142,14 +213,21 @@ func (s *TaskServer) DeleteTask(c echo.Context) error {
 // @Tags Analytics
 // @Accept json
 // @Produce json
+// @Param project_id query int false "Project ID"
 // @Success 200 {object} domain.Analyse
+// @Failure 400 {object} string
 // @Failure 500 {object} string
 // @Router /api/v1/analytics [get]
 func (s *TaskServer) GetAnalytics(c echo.Context) error {
+	projectID, err := projectIDParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
 	var analytics *domain.Analyse
-	analytics, err := s.service.GetAnalytics(c.Request().Context())
+	analytics, err = s.service.GetAnalytics(c.Request().Context(), projectID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to get analytics"})
+		return taskError(c, err, "Failed to get analytics")
 	}
 
 	return c.JSON(http.StatusOK, analytics)
@@ -161,6 +239,7 @@ func (s *TaskServer) GetAnalytics(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param tasks body []domain.TaskRequest true "Tasks"
+// @Param project_id query int false "Project ID"
 // @Success 200 {object} nil
 // @Failure 400 {object} string
 // @Failure 500 {object} string
@@ -168,7 +247,12 @@ func (s *TaskServer) GetAnalytics(c echo.Context) error {
 func (s *TaskServer) ImportTasks(c echo.Context) error {
 	var tasks []*domain.TaskRequest
 
-	err := json.NewDecoder(c.Request().Body).Decode(&tasks)
+	projectID, err := projectIDParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	err = json.NewDecoder(c.Request().Body).Decode(&tasks)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
 	}
@@ -192,9 +276,9 @@ func (s *TaskServer) ImportTasks(c echo.Context) error {
 		tasksD = append(tasksD, taskD)
 	}
 
-	err = s.service.ImportTasks(c.Request().Context(), tasksD)
+	err = s.service.ImportTasks(c.Request().Context(), projectID, tasksD)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to import tasks"})
+		return taskError(c, err, "Failed to import tasks")
 	}
 
 	return c.JSON(http.StatusOK, echo.Map{"message": "Tasks imported successfully"})
@@ -205,16 +289,23 @@ func (s *TaskServer) ImportTasks(c echo.Context) error {
 // @Tags Tasks
 // @Accept json
 // @Produce json
+// @Param project_id query int false "Project ID"
 // @Success 200 {object} []domain.TaskResponse
+// @Failure 400 {object} string
 // @Failure 500 {object} string
 // @Router /api/v1/tasks/export [get]
 func (s *TaskServer) ExportTasks(c echo.Context) error { // Service
-	tasks, err := s.service.ExportTasks(c.Request().Context())
+	projectID, err := projectIDParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	tasks, err := s.service.ExportTasks(c.Request().Context(), projectID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to export tasks"})
+		return taskError(c, err, "Failed to export tasks")
 	}
 
-	tasksR := make([]*domain.TaskResponse, len(tasks))
+	tasksR := make([]*domain.TaskResponse, 0, len(tasks))
 	for _, task := range tasks {
 		tasksR = append(tasksR, domain.TaskToTaskResponse(task))
 	}
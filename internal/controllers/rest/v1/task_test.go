@@ -67,7 +67,7 @@ func TestUpdateTask(t *testing.T) {
 	c.SetParamNames("id")
 	c.SetParamValues("1")
 
-	mockService.On("UpdateTask", mock.Anything, mock.Anything).Return(&domain.Task{}, nil)
+	mockService.On("UpdateTask", mock.Anything, mock.Anything, "").Return(&domain.Task{}, nil)
 
 	if assert.NoError(t, server.UpdateTask(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
@@ -77,6 +77,44 @@ func TestUpdateTask(t *testing.T) {
 	}
 }
 
+func TestUpdateTaskNotFound(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	taskReq := domain.TaskRequest{Title: "Task 1", Status: "pending", Due_date: "2025-12-12 15:04:05", Priority: "low", Description: "Description 1"}
+	jsonReq, _ := json.Marshal(taskReq)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/tasks/1", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService.On("UpdateTask", mock.Anything, mock.Anything, "").Return(nil, domain.TaskNotFound)
+
+	if assert.NoError(t, server.UpdateTask(c)) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestGetTasksUnauthorized(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("GetTasks", mock.Anything, mock.Anything).Return(nil, domain.Unauthorized)
+
+	if assert.NoError(t, server.GetTasks(c)) {
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	}
+}
+
 // TODO: Test ImportTasks
 
 func TestDeleteTask(t *testing.T) {
@@ -90,7 +128,7 @@ func TestDeleteTask(t *testing.T) {
 	c.SetParamNames("id")
 	c.SetParamValues("1")
 
-	mockService.On("DeleteTask", mock.Anything, mock.Anything).Return(nil)
+	mockService.On("DeleteTask", mock.Anything, mock.Anything, "").Return(nil)
 
 	if assert.NoError(t, server.DeleteTask(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
@@ -107,7 +145,7 @@ func TestGetAnalytics(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	mockService.On("GetAnalytics", mock.Anything).Return(&domain.Analyse{}, nil)
+	mockService.On("GetAnalytics", mock.Anything, 0).Return(&domain.Analyse{}, nil)
 
 	if assert.NoError(t, server.GetAnalytics(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
@@ -132,7 +170,7 @@ func TestImportTasks(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	mockService.On("ImportTasks", mock.Anything, mock.Anything).Return(nil)
+	mockService.On("ImportTasks", mock.Anything, 0, mock.Anything).Return(nil)
 
 	if assert.NoError(t, server.ImportTasks(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
@@ -145,11 +183,11 @@ func TestExportTasks(t *testing.T) {
 	server := v1.NewTaskControllers(mockService)
 	e := echo.New()
 
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/export", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/export?project_id=3", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	mockService.On("ExportTasks", mock.Anything).Return([]*domain.Task{}, nil)
+	mockService.On("ExportTasks", mock.Anything, 3).Return([]*domain.Task{}, nil)
 
 	if assert.NoError(t, server.ExportTasks(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
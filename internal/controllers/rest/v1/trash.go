@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+// @Summary Get trash
+// @Description Get the deleted tasks, most recent first. Subtasks deleted together with their parent are not listed, they are restored with it
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Param project_id query int false "Project ID"
+// @Success 200 {object} []domain.TaskResponse
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/trash [get]
+func (s *TaskServer) GetTrash(c echo.Context) error {
+	projectID, err := projectIDParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	tasks, err := s.service.GetTrash(c.Request().Context(), projectID)
+	if err != nil {
+		return taskError(c, err, "Failed to get trash")
+	}
+
+	tasksR := make([]*domain.TaskResponse, 0, len(tasks))
+	for _, task := range tasks {
+		tasksR = append(tasksR, domain.TaskToTaskResponse(task))
+	}
+
+	return c.JSON(http.StatusOK, tasksR)
+}
+
+// @Summary Restore task
+// @Description Take a deleted task out of the trash together with the subtasks deleted with it. If its parent is still in the trash, the task becomes a top-level task
+// @Tags Tasks
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} domain.TaskResponse
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/tasks/{id}/restore [post]
+func (s *TaskServer) RestoreTask(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	task, err := s.service.RestoreTask(c.Request().Context(), id)
+	if err != nil {
+		return taskError(c, err, "Failed to restore task")
+	}
+
+	return c.JSON(http.StatusOK, domain.TaskToTaskResponse(task))
+}
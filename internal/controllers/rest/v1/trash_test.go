@@ -0,0 +1,82 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+func TestGetTrash(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/trash?project_id=2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	deletedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockService.On("GetTrash", mock.Anything, 2).Return([]*domain.Task{
+		{ID: 1, ProjectID: 2, Title: "Deleted", DeletedAt: &deletedAt},
+	}, nil)
+
+	if assert.NoError(t, server.GetTrash(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp []*domain.TaskResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		if assert.Len(t, resp, 1) {
+			assert.Equal(t, "2025-03-01 12:00:00", resp[0].DeletedAt)
+		}
+	}
+}
+
+func TestRestoreTask(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/1/restore", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService.On("RestoreTask", mock.Anything, 1).Return(&domain.Task{ID: 1, Title: "Restored"}, nil)
+
+	if assert.NoError(t, server.RestoreTask(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp domain.TaskResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "Restored", resp.Title)
+		assert.Empty(t, resp.DeletedAt)
+	}
+}
+
+func TestRestoreTaskNotInTrash(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/1/restore", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService.On("RestoreTask", mock.Anything, 1).Return(nil, domain.TaskNotFound)
+
+	if assert.NoError(t, server.RestoreTask(c)) {
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+}
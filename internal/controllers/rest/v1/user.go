@@ -2,12 +2,16 @@ package v1
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/wazwki/skillsrock/internal/controllers/rest"
 	"github.com/wazwki/skillsrock/internal/domain"
 	"github.com/wazwki/skillsrock/internal/service"
+	"github.com/wazwki/skillsrock/pkg/jwtutil"
+	"github.com/wazwki/skillsrock/pkg/ratelimit"
 )
 
 type UserServer struct {
@@ -26,6 +30,8 @@ func NewUserControllers(s service.UserServiceInterface) rest.UserControllersInte
 // @Param user body domain.UserRequest true "User"
 // @Success 201 {object} domain.UserResponse
 // @Failure 400 {object} string
+// @Failure 409 {object} string
+// @Failure 429 {object} string
 // @Failure 500 {object} string
 // @Router /api/v1/auth/register [post]
 func (s *UserServer) Register(c echo.Context) error {
@@ -36,6 +42,9 @@ func (s *UserServer) Register(c echo.Context) error {
 
 	createdUser, err := s.service.CreateUser(c.Request().Context(), domain.UserRequestToUser(user))
 	if err != nil {
+		if errors.Is(err, domain.UserAlreadyExists) {
+			return c.JSON(http.StatusConflict, echo.Map{"error": "User already exists"})
+		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to create user"})
 	}
 
@@ -43,25 +52,206 @@ func (s *UserServer) Register(c echo.Context) error {
 }
 
 // @Summary Login user
-// @Description Login user
+// @Description Verify credentials and issue access and refresh tokens. Users with two-factor authentication get an MFA token instead (domain.MFAChallengeResponse), to be exchanged at /api/v1/auth/mfa
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Param user body domain.UserRequest true "User"
-// @Success 200 {object} nil
+// @Success 200 {object} domain.TokensResponse
 // @Failure 400 {object} string
+// @Failure 401 {object} string
+// @Failure 403 {object} string
+// @Failure 429 {object} string
 // @Failure 500 {object} string
 // @Router /api/v1/auth/login [post]
 func (s *UserServer) Login(c echo.Context) error {
 	var user *domain.UserRequest
-	if err := json.NewDecoder(c.Request().Body).Decode(&user); err != nil {
+	if err := json.NewDecoder(c.Request().Body).Decode(&user); err != nil || user == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	tokens, err := s.service.Login(c.Request().Context(), domain.UserRequestToUser(user))
+	if err != nil {
+		return loginError(c, err)
+	}
+
+	if tokens.MFAToken != "" {
+		return c.JSON(http.StatusOK, domain.TokensToMFAChallengeResponse(tokens))
+	}
+
+	setAuthCookies(c, tokens)
+
+	return c.JSON(http.StatusOK, domain.TokensToTokensResponse(tokens))
+}
+
+// @Summary Verify second factor
+// @Description Exchange the MFA token returned by login and a TOTP or recovery code for access and refresh tokens
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param mfa body domain.MFALoginRequest true "MFA token and code"
+// @Success 200 {object} domain.TokensResponse
+// @Failure 400 {object} string
+// @Failure 401 {object} string
+// @Failure 403 {object} string
+// @Failure 429 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/auth/mfa [post]
+func (s *UserServer) VerifyMFA(c echo.Context) error {
+	var req *domain.MFALoginRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil || req == nil || req.MFAToken == "" {
 		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
 	}
 
-	err := s.service.CheckUser(c.Request().Context(), domain.UserRequestToUser(user))
+	tokens, err := s.service.VerifyMFA(c.Request().Context(), req.MFAToken, req.Code)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, echo.Map{"error": "User not found"})
+		return loginError(c, err)
+	}
+
+	setAuthCookies(c, tokens)
+
+	return c.JSON(http.StatusOK, domain.TokensToTokensResponse(tokens))
+}
+
+func loginError(c echo.Context, err error) error {
+	var locked *domain.LockedOutError
+	switch {
+	case errors.As(err, &locked):
+		c.Response().Header().Set("Retry-After", ratelimit.RetryAfter(locked.RetryAfter))
+		return c.JSON(http.StatusTooManyRequests, echo.Map{"error": "Too many failed attempts"})
+	case errors.Is(err, domain.UserNotFound):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Invalid credentials"})
+	case errors.Is(err, domain.InvalidToken):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Invalid token"})
+	case errors.Is(err, domain.InvalidMFACode):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Invalid code"})
+	case errors.Is(err, domain.UserDisabled):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Account disabled"})
+	case errors.Is(err, domain.PasswordResetRequired):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Password reset required"})
+	case errors.Is(err, domain.OIDCDisabled):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "OIDC login is not configured"})
+	case errors.Is(err, domain.IdentityInUse):
+		return c.JSON(http.StatusConflict, echo.Map{"error": "Account linked to another user"})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to login"})
+}
+
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new token pair. The refresh token is taken from the body or the Refresh cookie and is rotated on every use
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param token body domain.RefreshRequest false "Refresh token"
+// @Success 200 {object} domain.TokensResponse
+// @Failure 401 {object} string
+// @Failure 403 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/auth/refresh [post]
+func (s *UserServer) Refresh(c echo.Context) error {
+	var req domain.RefreshRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if req.RefreshToken == "" {
+		if cookie, err := c.Cookie("Refresh"); err == nil {
+			req.RefreshToken = cookie.Value
+		}
+	}
+
+	if req.RefreshToken == "" {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Invalid token"})
 	}
 
+	tokens, err := s.service.Refresh(c.Request().Context(), req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, domain.InvalidToken) || errors.Is(err, domain.TokenReused) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Invalid token"})
+		}
+		if errors.Is(err, domain.UserDisabled) {
+			return c.JSON(http.StatusForbidden, echo.Map{"error": "Account disabled"})
+		}
+		if errors.Is(err, domain.PasswordResetRequired) {
+			return c.JSON(http.StatusForbidden, echo.Map{"error": "Password reset required"})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to refresh tokens"})
+	}
+
+	setAuthCookies(c, tokens)
+
+	return c.JSON(http.StatusOK, domain.TokensToTokensResponse(tokens))
+}
+
+// @Summary Logout
+// @Description Revoke the current access token and its refresh token
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Success 200 {object} nil
+// @Failure 401 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/auth/logout [post]
+func (s *UserServer) Logout(c echo.Context) error {
+	claims, ok := c.Get("claims").(*jwtutil.CustomClaims)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Unauthorized"})
+	}
+
+	if err := s.service.Logout(c.Request().Context(), claims); err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to logout"})
+	}
+
+	clearAuthCookies(c)
+
+	return c.NoContent(http.StatusOK)
+}
+
+// @Summary Logout everywhere
+// @Description Revoke every session of the current user
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Success 200 {object} nil
+// @Failure 401 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/auth/logout/all [post]
+func (s *UserServer) LogoutAll(c echo.Context) error {
+	claims, ok := c.Get("claims").(*jwtutil.CustomClaims)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Unauthorized"})
+	}
+
+	if err := s.service.LogoutAll(c.Request().Context(), claims); err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to logout"})
+	}
+
+	clearAuthCookies(c)
+
 	return c.NoContent(http.StatusOK)
 }
+
+func setAuthCookies(c echo.Context, tokens *domain.Tokens) {
+	c.SetCookie(&http.Cookie{
+		Name:     "Authorization",
+		Value:    tokens.AccessToken,
+		Path:     "/",
+		Expires:  tokens.AccessExpiresAt,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	c.SetCookie(&http.Cookie{
+		Name:     "Refresh",
+		Value:    tokens.RefreshToken,
+		Path:     "/api/v1/auth",
+		Expires:  tokens.RefreshExpiresAt,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+func clearAuthCookies(c echo.Context) {
+	c.SetCookie(&http.Cookie{Name: "Authorization", Path: "/", MaxAge: -1, HttpOnly: true})
+	c.SetCookie(&http.Cookie{Name: "Refresh", Path: "/api/v1/auth", MaxAge: -1, HttpOnly: true})
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -13,6 +14,7 @@ import (
 	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
 	"github.com/wazwki/skillsrock/internal/domain"
 	"github.com/wazwki/skillsrock/internal/service/mocks"
+	"github.com/wazwki/skillsrock/pkg/jwtutil"
 )
 
 func TestRegisterUser(t *testing.T) {
@@ -35,6 +37,26 @@ func TestRegisterUser(t *testing.T) {
 	}
 }
 
+func TestRegisterUserConflict(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	userReq := domain.UserRequest{Name: "John Doe", Password: "password"}
+	jsonReq, _ := json.Marshal(userReq)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("CreateUser", mock.Anything, mock.Anything).Return(nil, domain.UserAlreadyExists)
+
+	if assert.NoError(t, server.Register(c)) {
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	}
+}
+
 func TestLoginUser(t *testing.T) {
 	mockService := mocks.NewUserServiceInterface(t)
 	server := v1.NewUserControllers(mockService)
@@ -48,9 +70,210 @@ func TestLoginUser(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	mockService.On("CheckUser", mock.Anything, mock.Anything).Return(nil)
+	mockService.On("Login", mock.Anything, mock.Anything).Return(&domain.Tokens{
+		AccessToken:      "access",
+		RefreshToken:     "refresh",
+		AccessExpiresAt:  time.Now().Add(time.Hour),
+		RefreshExpiresAt: time.Now().Add(24 * time.Hour),
+	}, nil)
 
 	if assert.NoError(t, server.Login(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var tokens domain.TokensResponse
+		assert.NoError(t, json.NewDecoder(rec.Body).Decode(&tokens))
+		assert.Equal(t, "access", tokens.AccessToken)
+		assert.Equal(t, "refresh", tokens.RefreshToken)
+		assert.Len(t, rec.Result().Cookies(), 2)
+	}
+}
+
+func TestLoginUserInvalidCredentials(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	userReq := domain.UserRequest{Name: "John Doe", Password: "wrong"}
+	jsonReq, _ := json.Marshal(userReq)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("Login", mock.Anything, mock.Anything).Return(nil, domain.UserNotFound)
+
+	if assert.NoError(t, server.Login(c)) {
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Empty(t, rec.Result().Cookies())
+	}
+}
+
+func TestLoginUserDisabled(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	userReq := domain.UserRequest{Name: "John Doe", Password: "password"}
+	jsonReq, _ := json.Marshal(userReq)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("Login", mock.Anything, mock.Anything).Return(nil, domain.UserDisabled)
+
+	if assert.NoError(t, server.Login(c)) {
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Empty(t, rec.Result().Cookies())
+	}
+}
+
+func TestLoginUserLockedOut(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	userReq := domain.UserRequest{Name: "John Doe", Password: "password"}
+	jsonReq, _ := json.Marshal(userReq)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("Login", mock.Anything, mock.Anything).Return(nil, &domain.LockedOutError{RetryAfter: 1500 * time.Millisecond})
+
+	if assert.NoError(t, server.Login(c)) {
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+	}
+}
+
+func TestLoginUserMFARequired(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	userReq := domain.UserRequest{Name: "John Doe", Password: "password"}
+	jsonReq, _ := json.Marshal(userReq)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("Login", mock.Anything, mock.Anything).Return(&domain.Tokens{
+		MFAToken:     "mfa",
+		MFAExpiresAt: time.Now().Add(5 * time.Minute),
+	}, nil)
+
+	if assert.NoError(t, server.Login(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Result().Cookies())
+
+		var challenge domain.MFAChallengeResponse
+		assert.NoError(t, json.NewDecoder(rec.Body).Decode(&challenge))
+		assert.True(t, challenge.MFARequired)
+		assert.Equal(t, "mfa", challenge.MFAToken)
+	}
+}
+
+func TestVerifyMFAInvalidCode(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	jsonReq, _ := json.Marshal(domain.MFALoginRequest{MFAToken: "mfa", Code: "000000"})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/mfa", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("VerifyMFA", mock.Anything, "mfa", "000000").Return(nil, domain.InvalidMFACode)
+
+	if assert.NoError(t, server.VerifyMFA(c)) {
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Empty(t, rec.Result().Cookies())
+	}
+}
+
+func TestRefreshFromCookie(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/refresh", nil)
+	req.AddCookie(&http.Cookie{Name: "Refresh", Value: "refresh"})
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("Refresh", mock.Anything, "refresh").Return(&domain.Tokens{
+		AccessToken:      "access2",
+		RefreshToken:     "refresh2",
+		AccessExpiresAt:  time.Now().Add(time.Hour),
+		RefreshExpiresAt: time.Now().Add(24 * time.Hour),
+	}, nil)
+
+	if assert.NoError(t, server.Refresh(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var tokens domain.TokensResponse
+		assert.NoError(t, json.NewDecoder(rec.Body).Decode(&tokens))
+		assert.Equal(t, "refresh2", tokens.RefreshToken)
+	}
+}
+
+func TestRefreshReusedToken(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	jsonReq, _ := json.Marshal(domain.RefreshRequest{RefreshToken: "refresh"})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/refresh", bytes.NewReader(jsonReq))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("Refresh", mock.Anything, "refresh").Return(nil, domain.TokenReused)
+
+	if assert.NoError(t, server.Refresh(c)) {
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/logout", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	claims := &jwtutil.CustomClaims{Type: "access", UserID: 1, Family: "family"}
+	c.Set("claims", claims)
+
+	mockService.On("Logout", mock.Anything, claims).Return(nil)
+
+	if assert.NoError(t, server.Logout(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
+
+func TestLogoutAllUnauthorized(t *testing.T) {
+	mockService := mocks.NewUserServiceInterface(t)
+	server := v1.NewUserControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/logout/all", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if assert.NoError(t, server.LogoutAll(c)) {
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
 	}
 }
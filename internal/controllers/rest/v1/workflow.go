@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wazwki/skillsrock/internal/controllers/rest"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service"
+)
+
+type WorkflowServer struct {
+	service service.WorkflowServiceInterface
+}
+
+func NewWorkflowControllers(s service.WorkflowServiceInterface) rest.WorkflowControllersInterface {
+	return &WorkflowServer{service: s}
+}
+
+func workflowError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, domain.Unauthorized):
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Unauthorized"})
+	case errors.Is(err, domain.Forbidden):
+		return c.JSON(http.StatusForbidden, echo.Map{"error": "Forbidden"})
+	case errors.Is(err, domain.ProjectNotFound):
+		return c.JSON(http.StatusNotFound, echo.Map{"error": "Project not found"})
+	case errors.Is(err, domain.InvalidWorkflow):
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid workflow"})
+	case errors.Is(err, domain.StatusInUse):
+		return c.JSON(http.StatusConflict, echo.Map{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"error": message})
+}
+
+// @Summary Get workflow
+// @Description Get the statuses and transitions of a project, or of the default workflow without project_id
+// @Tags Workflows
+// @Accept json
+// @Produce json
+// @Param project_id query int false "Project ID"
+// @Success 200 {object} domain.WorkflowResponse
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/workflow [get]
+func (s *WorkflowServer) GetWorkflow(c echo.Context) error {
+	projectID, err := projectIDParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	workflow, err := s.service.GetWorkflow(c.Request().Context(), projectID)
+	if err != nil {
+		return workflowError(c, err, "Failed to get workflow")
+	}
+
+	return c.JSON(http.StatusOK, domain.WorkflowToWorkflowResponse(workflow))
+}
+
+// @Summary Set workflow
+// @Description Replace the workflow of a project as its owner, or the default workflow as an admin. Statuses used by tasks cannot be removed
+// @Tags Workflows
+// @Accept json
+// @Produce json
+// @Param workflow body domain.WorkflowRequest true "Workflow"
+// @Param project_id query int false "Project ID"
+// @Success 200 {object} domain.WorkflowResponse
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/workflow [put]
+func (s *WorkflowServer) SetWorkflow(c echo.Context) error {
+	projectID, err := projectIDParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	var workflow *domain.WorkflowRequest
+	if err := json.NewDecoder(c.Request().Body).Decode(&workflow); err != nil || workflow == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	dWorkflow := domain.WorkflowFromWorkflowRequest(workflow)
+	dWorkflow.ProjectID = projectID
+
+	updated, err := s.service.SetWorkflow(c.Request().Context(), dWorkflow)
+	if err != nil {
+		return workflowError(c, err, "Failed to set workflow")
+	}
+
+	return c.JSON(http.StatusOK, domain.WorkflowToWorkflowResponse(updated))
+}
+
+// @Summary Reset workflow
+// @Description Return a project to the default workflow, which has to contain the statuses of its tasks
+// @Tags Workflows
+// @Accept json
+// @Produce json
+// @Param project_id query int true "Project ID"
+// @Success 200 {object} nil
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Router /api/v1/workflow [delete]
+func (s *WorkflowServer) ResetWorkflow(c echo.Context) error {
+	projectID, err := projectIDParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Invalid input"})
+	}
+
+	if err := s.service.ResetWorkflow(c.Request().Context(), projectID); err != nil {
+		return workflowError(c, err, "Failed to reset workflow")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
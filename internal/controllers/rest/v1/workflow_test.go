@@ -0,0 +1,97 @@
+package v1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "github.com/wazwki/skillsrock/internal/controllers/rest/v1"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service/mocks"
+)
+
+func TestGetWorkflow(t *testing.T) {
+	mockService := mocks.NewWorkflowServiceInterface(t)
+	server := v1.NewWorkflowControllers(mockService)
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/workflow?project_id=3", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("GetWorkflow", mock.Anything, 3).Return(&domain.Workflow{
+		ProjectID: 3,
+		Custom:    true,
+		Statuses: []*domain.WorkflowStatus{
+			{Name: "backlog", Category: domain.StatusCategoryTodo},
+			{Name: "review", Category: domain.StatusCategoryInProgress},
+			{Name: "shipped", Category: domain.StatusCategoryDone},
+		},
+		Transitions: []*domain.WorkflowTransition{{From: "backlog", To: "review"}, {From: "review", To: "shipped"}},
+	}, nil)
+
+	if assert.NoError(t, server.GetWorkflow(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var resp domain.WorkflowResponse
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.True(t, resp.Custom)
+		if assert.Len(t, resp.Statuses, 3) {
+			assert.Equal(t, domain.WorkflowStatusResponse{Name: "review", Category: domain.StatusCategoryInProgress}, *resp.Statuses[1])
+		}
+		if assert.Len(t, resp.Transitions, 2) {
+			assert.Equal(t, domain.WorkflowTransitionResponse{From: "review", To: "shipped"}, *resp.Transitions[1])
+		}
+	}
+}
+
+func TestSetWorkflowStatusInUse(t *testing.T) {
+	mockService := mocks.NewWorkflowServiceInterface(t)
+	server := v1.NewWorkflowControllers(mockService)
+	e := echo.New()
+
+	body, _ := json.Marshal(domain.WorkflowRequest{
+		Statuses: []*domain.WorkflowStatusRequest{
+			{Name: "todo", Category: domain.StatusCategoryTodo},
+			{Name: "done", Category: domain.StatusCategoryDone},
+		},
+		Transitions: []*domain.WorkflowTransitionRequest{{From: "todo", To: "done"}},
+	})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/workflow?project_id=3", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService.On("SetWorkflow", mock.Anything, mock.MatchedBy(func(workflow *domain.Workflow) bool {
+		return workflow.ProjectID == 3 && len(workflow.Statuses) == 2 && workflow.Transitions[0].To == "done"
+	})).Return(nil, fmt.Errorf("%w: in_progress", domain.StatusInUse))
+
+	if assert.NoError(t, server.SetWorkflow(c)) {
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Contains(t, rec.Body.String(), "in_progress")
+	}
+}
+
+func TestUpdateTaskInvalidTransition(t *testing.T) {
+	mockService := mocks.NewTaskServiceInterface(t)
+	server := v1.NewTaskControllers(mockService)
+	e := echo.New()
+
+	body, _ := json.Marshal(domain.TaskRequest{Title: "Task", Status: "pending", Priority: "low"})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/tasks/1", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockService.On("UpdateTask", mock.Anything, mock.Anything, "").Return(nil, domain.InvalidTransition)
+
+	if assert.NoError(t, server.UpdateTask(c)) {
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	}
+}
@@ -0,0 +1,11 @@
+package rest
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type WorkflowControllersInterface interface {
+	GetWorkflow(c echo.Context) error
+	SetWorkflow(c echo.Context) error
+	ResetWorkflow(c echo.Context) error
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	APIKeyNotFound = errors.New("API key not found")
+	InvalidScope   = errors.New("Invalid scope")
+)
+
+const (
+	ScopeTasksRead     = "tasks:read"
+	ScopeTasksWrite    = "tasks:write"
+	ScopeAnalyticsRead = "analytics:read"
+	ScopeProjectsRead  = "projects:read"
+	ScopeProjectsWrite = "projects:write"
+)
+
+var scopes = map[string]bool{
+	ScopeTasksRead:     true,
+	ScopeTasksWrite:    true,
+	ScopeAnalyticsRead: true,
+	ScopeProjectsRead:  true,
+	ScopeProjectsWrite: true,
+}
+
+func ValidScope(scope string) bool {
+	return scopes[scope]
+}
+
+type APIKey struct {
+	ID         int
+	UserID     int
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	// UserRole is the role of the owner, the key never grants more than it.
+	UserRole string
+}
+
+type APIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type APIKeyResponse struct {
+	ID         int      `json:"id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+	CreatedAt  string   `json:"created_at"`
+	LastUsedAt string   `json:"last_used_at,omitempty"`
+}
+
+// CreatedAPIKeyResponse is returned once on creation and is the only time
+// the plaintext key is available.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+func APIKeyFromAPIKeyRequest(key *APIKeyRequest) *APIKey {
+	return &APIKey{
+		Name:   key.Name,
+		Scopes: key.Scopes,
+	}
+}
+
+func APIKeyToAPIKeyResponse(key *APIKey) *APIKeyResponse {
+	resp := &APIKeyResponse{
+		ID:        key.ID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    key.Scopes,
+		CreatedAt: key.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if key.LastUsedAt != nil {
+		resp.LastUsedAt = key.LastUsedAt.Format("2006-01-02 15:04:05")
+	}
+	return resp
+}
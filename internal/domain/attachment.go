@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	AttachmentNotFound        = errors.New("Attachment not found")
+	InvalidAttachment         = errors.New("Invalid attachment")
+	AttachmentTooLarge        = errors.New("Attachment too large")
+	UnsupportedAttachmentType = errors.New("Unsupported attachment type")
+)
+
+const maxAttachmentNameLength = 255
+
+// AttachmentLimits bound uploads. Types are matched against the content type
+// detected from the file, not the one sent by the client.
+type AttachmentLimits struct {
+	MaxSize int64
+	Types   []string
+}
+
+func (l AttachmentLimits) AllowsType(contentType string) bool {
+	contentType, _, _ = strings.Cut(contentType, ";")
+	for _, allowed := range l.Types {
+		if strings.EqualFold(strings.TrimSpace(allowed), strings.TrimSpace(contentType)) {
+			return true
+		}
+	}
+	return false
+}
+
+type Attachment struct {
+	ID          int
+	TaskID      int
+	UserID      int
+	Name        string
+	ContentType string
+	Size        int64
+	// Checksum is the hex encoded SHA-256 of the contents.
+	Checksum  string
+	BlobKey   string
+	CreatedAt time.Time
+}
+
+type AttachmentResponse struct {
+	ID          int    `json:"id"`
+	UserID      int    `json:"user_id,omitempty"`
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Checksum    string `json:"checksum"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// AttachmentName strips directories and control characters from an uploaded
+// file name and shortens it, keeping the extension.
+func AttachmentName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "." || name == "/" {
+		return ""
+	}
+
+	if utf8.RuneCountInString(name) > maxAttachmentNameLength {
+		ext := filepath.Ext(name)
+		if utf8.RuneCountInString(ext) > 16 {
+			ext = ""
+		}
+		runes := []rune(strings.TrimSuffix(name, ext))
+		name = string(runes[:maxAttachmentNameLength-utf8.RuneCountInString(ext)]) + ext
+	}
+	return name
+}
+
+func AttachmentToAttachmentResponse(attachment *Attachment) *AttachmentResponse {
+	return &AttachmentResponse{
+		ID:          attachment.ID,
+		UserID:      attachment.UserID,
+		Name:        attachment.Name,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Checksum:    attachment.Checksum,
+		CreatedAt:   attachment.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	CommentNotFound      = errors.New("Comment not found")
+	InvalidComment       = errors.New("Invalid comment")
+	InvalidParentComment = errors.New("Invalid parent comment")
+)
+
+const maxCommentLength = 10000
+
+// Comment bodies are markdown, stored and returned as written. A deleted
+// comment with replies keeps its place in the thread with an empty body.
+type Comment struct {
+	ID        int
+	TaskID    int
+	UserID    int
+	Author    string
+	ParentID  int
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Deleted   bool
+	Replies   []*Comment
+}
+
+type CommentRequest struct {
+	// ParentID is only read on creation.
+	ParentID int    `json:"parent_id,omitempty"`
+	Body     string `json:"body"`
+}
+
+type CommentResponse struct {
+	ID        int                `json:"id"`
+	ParentID  int                `json:"parent_id,omitempty"`
+	UserID    int                `json:"user_id,omitempty"`
+	Author    string             `json:"author,omitempty"`
+	Body      string             `json:"body"`
+	Edited    bool               `json:"edited"`
+	Deleted   bool               `json:"deleted"`
+	CreatedAt string             `json:"created_at"`
+	UpdatedAt string             `json:"updated_at"`
+	Replies   []*CommentResponse `json:"replies,omitempty"`
+}
+
+type CommentPageResponse struct {
+	Total    int                `json:"total"`
+	Limit    int                `json:"limit"`
+	Offset   int                `json:"offset"`
+	Comments []*CommentResponse `json:"comments"`
+}
+
+func ValidCommentBody(body string) bool {
+	return strings.TrimSpace(body) != "" && utf8.RuneCountInString(body) <= maxCommentLength
+}
+
+func CommentFromCommentRequest(comment *CommentRequest) *Comment {
+	return &Comment{
+		ParentID: comment.ParentID,
+		Body:     comment.Body,
+	}
+}
+
+// CommentToCommentResponse converts the comment with its replies.
+func CommentToCommentResponse(comment *Comment) *CommentResponse {
+	resp := &CommentResponse{
+		ID:        comment.ID,
+		ParentID:  comment.ParentID,
+		UserID:    comment.UserID,
+		Author:    comment.Author,
+		Body:      comment.Body,
+		Edited:    comment.UpdatedAt.After(comment.CreatedAt),
+		Deleted:   comment.Deleted,
+		CreatedAt: comment.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: comment.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+	for _, reply := range comment.Replies {
+		resp.Replies = append(resp.Replies, CommentToCommentResponse(reply))
+	}
+	return resp
+}
+
+// BuildCommentThreads nests the comments, ordered by id, under their parents
+// and returns the top-level ones.
+func BuildCommentThreads(comments []*Comment) []*Comment {
+	byID := make(map[int]*Comment, len(comments))
+	for _, comment := range comments {
+		byID[comment.ID] = comment
+	}
+
+	roots := make([]*Comment, 0)
+	for _, comment := range comments {
+		if parent, ok := byID[comment.ParentID]; ok {
+			parent.Replies = append(parent.Replies, comment)
+		} else {
+			roots = append(roots, comment)
+		}
+	}
+	return roots
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+	"slices"
+)
+
+type contextKey string
+
+const (
+	userIDKey contextKey = "user_id"
+	scopesKey contextKey = "scopes"
+	roleKey   contextKey = "role"
+
+	sessionIDKey contextKey = "session_id"
+	clientIPKey  contextKey = "client_ip"
+	userAgentKey contextKey = "user_agent"
+)
+
+func ContextWithUserID(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+func UserIDFromContext(ctx context.Context) (int, error) {
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok || userID == 0 {
+		return 0, Unauthorized
+	}
+	return userID, nil
+}
+
+func ContextWithRole(ctx context.Context, role string) context.Context {
+	return context.WithValue(ctx, roleKey, role)
+}
+
+// RoleFromContext returns the user role of the request, empty if unknown.
+func RoleFromContext(ctx context.Context) string {
+	role, _ := ctx.Value(roleKey).(string)
+	return role
+}
+
+// ContextWithScopes marks the request as authenticated with an API key that
+// is limited to the given scopes.
+func ContextWithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesKey, scopes)
+}
+
+// ScopesFromContext returns the API key scopes of the request; ok is false
+// for interactive sessions, which are not limited by scopes.
+func ScopesFromContext(ctx context.Context) (scopes []string, ok bool) {
+	scopes, ok = ctx.Value(scopesKey).([]string)
+	return scopes, ok
+}
+
+func HasScope(ctx context.Context, scope string) bool {
+	scopes, ok := ScopesFromContext(ctx)
+	return !ok || slices.Contains(scopes, scope)
+}
+
+// ContextWithSessionID marks the request as made within the session, i.e.
+// the refresh token family, of its access token.
+func ContextWithSessionID(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionIDKey, sessionID)
+}
+
+// SessionIDFromContext returns the session of the request, empty for API keys.
+func SessionIDFromContext(ctx context.Context) string {
+	sessionID, _ := ctx.Value(sessionIDKey).(string)
+	return sessionID
+}
+
+func ContextWithClient(ctx context.Context, ip, userAgent string) context.Context {
+	ctx = context.WithValue(ctx, clientIPKey, ip)
+	return context.WithValue(ctx, userAgentKey, userAgent)
+}
+
+// ClientFromContext returns the address and user agent the request came from.
+func ClientFromContext(ctx context.Context) (ip, userAgent string) {
+	ip, _ = ctx.Value(clientIPKey).(string)
+	userAgent, _ = ctx.Value(userAgentKey).(string)
+	return ip, userAgent
+}
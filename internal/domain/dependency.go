@@ -0,0 +1,82 @@
+package domain
+
+import "errors"
+
+var (
+	DependencyNotFound = errors.New("Dependency not found")
+	DependencyCycle    = errors.New("Dependency cycle")
+	TaskBlocked        = errors.New("Task is blocked by open tasks")
+)
+
+// TaskDependency means that TaskID is blocked by BlockerID until the
+// blocker is done.
+type TaskDependency struct {
+	TaskID    int
+	BlockerID int
+}
+
+type DependencyRequest struct {
+	BlockerID int `json:"blocker_id"`
+}
+
+type CriticalPathResponse struct {
+	Length int             `json:"length"`
+	Tasks  []*TaskResponse `json:"tasks"`
+}
+
+// CriticalPath returns the longest chain of open tasks that has to be done
+// before the task, starting with the first one to work on and ending with
+// the task itself. Done tasks no longer block and end a chain.
+func CriticalPath(taskID int, tasks []*Task, dependencies []TaskDependency) []*Task {
+	byID := make(map[int]*Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	blockers := make(map[int][]int)
+	for _, dep := range dependencies {
+		if blocker, ok := byID[dep.BlockerID]; ok && !blocker.Done() {
+			blockers[dep.TaskID] = append(blockers[dep.TaskID], dep.BlockerID)
+		}
+	}
+
+	length := make(map[int]int)
+	next := make(map[int]int)
+	var visit func(id int) int
+	visit = func(id int) int {
+		if l, ok := length[id]; ok {
+			return l
+		}
+		// Guards against cycles, which insertion already rules out.
+		length[id] = 1
+
+		best, bestID := 0, 0
+		for _, blocker := range blockers[id] {
+			l := visit(blocker)
+			if l > best || (l == best && blocker < bestID) {
+				best, bestID = l, blocker
+			}
+		}
+		length[id] = 1 + best
+		next[id] = bestID
+		return length[id]
+	}
+
+	root, ok := byID[taskID]
+	if !ok {
+		return nil
+	}
+	if !root.Done() {
+		visit(taskID)
+	}
+
+	path := []*Task{root}
+	for id := next[taskID]; id != 0; id = next[id] {
+		path = append(path, byID[id])
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
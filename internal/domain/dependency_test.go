@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+func pathIDs(path []*domain.Task) []int {
+	ids := make([]int, 0, len(path))
+	for _, task := range path {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func TestCriticalPath(t *testing.T) {
+	// 1 is blocked by 2 and 3, 3 by 4 and 5, and 5 is already done.
+	path := domain.CriticalPath(1, []*domain.Task{
+		task(1, 0, domain.StatusCategoryTodo),
+		task(2, 0, domain.StatusCategoryTodo),
+		task(3, 0, domain.StatusCategoryTodo),
+		task(4, 0, domain.StatusCategoryInProgress),
+		task(5, 0, domain.StatusCategoryDone),
+	}, []domain.TaskDependency{
+		{TaskID: 1, BlockerID: 2},
+		{TaskID: 1, BlockerID: 3},
+		{TaskID: 3, BlockerID: 4},
+		{TaskID: 3, BlockerID: 5},
+	})
+
+	assert.Equal(t, []int{4, 3, 1}, pathIDs(path))
+}
+
+func TestCriticalPathTies(t *testing.T) {
+	tasks := []*domain.Task{
+		task(1, 0, domain.StatusCategoryTodo),
+		task(2, 0, domain.StatusCategoryTodo),
+		task(3, 0, domain.StatusCategoryTodo),
+		task(4, 0, domain.StatusCategoryTodo),
+		task(5, 0, domain.StatusCategoryTodo),
+	}
+
+	// 1 is blocked by the chains 5 -> 3 and 4 -> 2 of equal length, the one
+	// with the lower blocker ID wins whatever the order of the dependencies.
+	dependencies := []domain.TaskDependency{
+		{TaskID: 1, BlockerID: 3},
+		{TaskID: 3, BlockerID: 5},
+		{TaskID: 1, BlockerID: 2},
+		{TaskID: 2, BlockerID: 4},
+	}
+	assert.Equal(t, []int{4, 2, 1}, pathIDs(domain.CriticalPath(1, tasks, dependencies)))
+
+	reversed := make([]domain.TaskDependency, 0, len(dependencies))
+	for i := len(dependencies) - 1; i >= 0; i-- {
+		reversed = append(reversed, dependencies[i])
+	}
+	assert.Equal(t, []int{4, 2, 1}, pathIDs(domain.CriticalPath(1, tasks, reversed)))
+}
+
+func TestCriticalPathDoneBlockers(t *testing.T) {
+	tasks := []*domain.Task{
+		task(1, 0, domain.StatusCategoryTodo),
+		task(2, 0, domain.StatusCategoryDone),
+		task(3, 0, domain.StatusCategoryTodo),
+		task(4, 0, domain.StatusCategoryTodo),
+	}
+
+	// The done task 2 ends the longer chain 3 -> 2 -> 1, so 4 -> 1 is left.
+	dependencies := []domain.TaskDependency{
+		{TaskID: 1, BlockerID: 2},
+		{TaskID: 2, BlockerID: 3},
+		{TaskID: 1, BlockerID: 4},
+	}
+	assert.Equal(t, []int{4, 1}, pathIDs(domain.CriticalPath(1, tasks, dependencies)))
+
+	// Only done blockers leave the task on its own.
+	assert.Equal(t, []int{1}, pathIDs(domain.CriticalPath(1, tasks, dependencies[:2])))
+
+	// A done task is not blocked at all.
+	assert.Equal(t, []int{2}, pathIDs(domain.CriticalPath(2, tasks, dependencies)))
+
+	assert.Nil(t, domain.CriticalPath(9, tasks, dependencies))
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TaskEvent is one entry of the task history. Events are recorded by the
+// database for every change of the task and are kept after it is deleted.
+// Action is created, updated or deleted. ActorID is 0 for changes made by
+// the system, such as auto-completion.
+type TaskEvent struct {
+	ID        int
+	TaskID    int
+	ActorID   int
+	Actor     string
+	Action    string
+	Changes   []FieldChange
+	CreatedAt time.Time
+}
+
+// FieldChange holds the old and new value of a task column as JSON, null
+// on creation and deletion respectively.
+type FieldChange struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old" swaggertype:"object"`
+	New   json.RawMessage `json:"new" swaggertype:"object"`
+}
+
+type TaskEventResponse struct {
+	ID        int           `json:"id"`
+	ActorID   int           `json:"actor_id,omitempty"`
+	Actor     string        `json:"actor,omitempty"`
+	Action    string        `json:"action"`
+	Changes   []FieldChange `json:"changes"`
+	CreatedAt string        `json:"created_at"`
+}
+
+type TaskHistoryResponse struct {
+	Total  int                  `json:"total"`
+	Limit  int                  `json:"limit"`
+	Offset int                  `json:"offset"`
+	Events []*TaskEventResponse `json:"events"`
+}
+
+func TaskEventToTaskEventResponse(event *TaskEvent) *TaskEventResponse {
+	return &TaskEventResponse{
+		ID:        event.ID,
+		ActorID:   event.ActorID,
+		Actor:     event.Actor,
+		Action:    event.Action,
+		Changes:   event.Changes,
+		CreatedAt: event.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	LabelNotFound      = errors.New("Label not found")
+	LabelAlreadyExists = errors.New("Label already exists")
+	InvalidLabel       = errors.New("Invalid label")
+	InvalidLabelMatch  = errors.New("Invalid label match")
+)
+
+const (
+	DefaultLabelColor  = "#9e9e9e"
+	maxLabelNameLength = 50
+)
+
+// Label filters match tasks carrying any or all of the given labels.
+const (
+	LabelMatchAny = "any"
+	LabelMatchAll = "all"
+)
+
+func ValidLabelMatch(match string) bool {
+	return match == LabelMatchAny || match == LabelMatchAll
+}
+
+var labelColorRe = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Label belongs to a project when ProjectID is set, otherwise it is a
+// personal label of UserID.
+type Label struct {
+	ID        int
+	UserID    int
+	ProjectID int
+	Name      string
+	Color     string
+}
+
+type LabelRequest struct {
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+}
+
+type LabelResponse struct {
+	ID        int    `json:"id"`
+	ProjectID int    `json:"project_id,omitempty"`
+	Name      string `json:"name"`
+	Color     string `json:"color"`
+}
+
+// NormalizeLabel trims the name, fills in the default colour and reports
+// whether the label is valid.
+func NormalizeLabel(label *Label) bool {
+	label.Name = strings.TrimSpace(label.Name)
+	if label.Color == "" {
+		label.Color = DefaultLabelColor
+	}
+	label.Color = strings.ToLower(label.Color)
+
+	return label.Name != "" && utf8.RuneCountInString(label.Name) <= maxLabelNameLength && labelColorRe.MatchString(label.Color)
+}
+
+func LabelFromLabelRequest(label *LabelRequest) *Label {
+	return &Label{
+		Name:  label.Name,
+		Color: label.Color,
+	}
+}
+
+func LabelToLabelResponse(label *Label) *LabelResponse {
+	return &LabelResponse{
+		ID:        label.ID,
+		ProjectID: label.ProjectID,
+		Name:      label.Name,
+		Color:     label.Color,
+	}
+}
+
+// LabelNames splits a comma separated list of label names and drops duplicates.
+func LabelNames(list string) []string {
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
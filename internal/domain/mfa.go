@@ -0,0 +1,52 @@
+package domain
+
+import "errors"
+
+var (
+	InvalidMFACode    = errors.New("Invalid MFA code")
+	MFAAlreadyEnabled = errors.New("MFA already enabled")
+	MFANotEnrolled    = errors.New("MFA not enrolled")
+)
+
+type MFACodeRequest struct {
+	Code string `json:"code"`
+}
+
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token"`
+	// Code is a TOTP code or one of the recovery codes.
+	Code string `json:"code"`
+}
+
+type MFADisableRequest struct {
+	Password string `json:"password"`
+}
+
+// MFAChallengeResponse is returned by login instead of tokens when the user
+// has two-factor authentication enabled.
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+type TOTPEnrolment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+type TOTPEnrolmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func TOTPEnrolmentToResponse(enrolment *TOTPEnrolment) *TOTPEnrolmentResponse {
+	return &TOTPEnrolmentResponse{
+		Secret:          enrolment.Secret,
+		ProvisioningURI: enrolment.ProvisioningURI,
+	}
+}
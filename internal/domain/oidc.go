@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	OIDCDisabled     = errors.New("OIDC login is not configured")
+	IdentityNotFound = errors.New("Identity not found")
+	IdentityInUse    = errors.New("Identity linked to another user")
+)
+
+// Identity links a user to an account at an external identity provider.
+type Identity struct {
+	ID        int
+	UserID    int
+	Issuer    string
+	Subject   string
+	Email     string
+	CreatedAt time.Time
+}
+
+// OIDCLogin is a started authorization request. State, Nonce and Verifier
+// are kept by the browser until the provider redirects back. LinkToken is
+// set when a signed-in user links their account at the provider instead of
+// signing in with it.
+type OIDCLogin struct {
+	URL       string
+	State     string
+	Nonce     string
+	Verifier  string
+	LinkToken string
+}
+
+type OIDCLinkResponse struct {
+	// URL is the authorization request to open in the browser.
+	URL string `json:"url"`
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "errors"
+
+var InvalidPage = errors.New("Invalid page")
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+// ValidPage fills in the default limit and reports whether the page is valid.
+func ValidPage(page *Page) bool {
+	if page.Limit == 0 {
+		page.Limit = DefaultPageLimit
+	}
+	return page.Limit > 0 && page.Limit <= MaxPageLimit && page.Offset >= 0
+}
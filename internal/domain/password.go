@@ -0,0 +1,28 @@
+package domain
+
+import "errors"
+
+var (
+	WeakPassword    = errors.New("Password is too short")
+	InvalidPassword = errors.New("Invalid password")
+)
+
+const MinPasswordLength = 8
+
+func ValidPassword(password string) bool {
+	return len(password) >= MinPasswordLength
+}
+
+type PasswordChangeRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type PasswordForgotRequest struct {
+	Name string `json:"name"`
+}
+
+type PasswordResetRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
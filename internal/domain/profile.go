@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"time"
+)
+
+var (
+	InvalidProfile      = errors.New("Invalid profile")
+	RecentLoginRequired = errors.New("Recent login required")
+)
+
+// localePattern accepts language tags like "en" or "pt-BR".
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
+type ProfileRequest struct {
+	DisplayName string `json:"display_name"`
+	Email       string `json:"email"`
+	Timezone    string `json:"timezone"`
+	Locale      string `json:"locale"`
+}
+
+type ProfileResponse struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Email       string `json:"email"`
+	Timezone    string `json:"timezone"`
+	Locale      string `json:"locale"`
+	Role        string `json:"role"`
+	MFAEnabled  bool   `json:"mfa_enabled"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type DeleteAccountRequest struct {
+	// Password is left empty for accounts without one, which confirm with a
+	// recent login instead.
+	Password string `json:"password"`
+}
+
+// ValidProfile checks the editable profile fields. Email may be empty,
+// timezone must be an IANA name.
+func ValidProfile(user *User) bool {
+	if len(user.DisplayName) > 100 {
+		return false
+	}
+	if user.Email != "" {
+		addr, err := mail.ParseAddress(user.Email)
+		if err != nil || addr.Address != user.Email {
+			return false
+		}
+	}
+	if _, err := time.LoadLocation(user.Timezone); err != nil || user.Timezone == "" {
+		return false
+	}
+	return localePattern.MatchString(user.Locale)
+}
+
+func ProfileFromProfileRequest(profile *ProfileRequest) *User {
+	return &User{
+		DisplayName: profile.DisplayName,
+		Email:       profile.Email,
+		Timezone:    profile.Timezone,
+		Locale:      profile.Locale,
+	}
+}
+
+func UserToProfileResponse(user *User) *ProfileResponse {
+	return &ProfileResponse{
+		ID:          user.ID,
+		Name:        user.Name,
+		DisplayName: user.DisplayName,
+		Email:       user.Email,
+		Timezone:    user.Timezone,
+		Locale:      user.Locale,
+		Role:        user.Role,
+		MFAEnabled:  user.MFAEnabled,
+		CreatedAt:   user.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ProjectNotFound       = errors.New("Project not found")
+	ProjectMemberNotFound = errors.New("Project member not found")
+	InvalidProjectRole    = errors.New("Invalid project role")
+	Forbidden             = errors.New("Forbidden")
+)
+
+const (
+	ProjectRoleOwner  = "owner"
+	ProjectRoleEditor = "editor"
+	ProjectRoleViewer = "viewer"
+)
+
+var projectRoleRank = map[string]int{
+	ProjectRoleViewer: 1,
+	ProjectRoleEditor: 2,
+	ProjectRoleOwner:  3,
+}
+
+func ValidProjectRole(role string) bool {
+	_, ok := projectRoleRank[role]
+	return ok
+}
+
+// ProjectRoleAtLeast reports whether role grants at least the rights of required.
+func ProjectRoleAtLeast(role, required string) bool {
+	return projectRoleRank[role] >= projectRoleRank[required] && projectRoleRank[role] > 0
+}
+
+type Project struct {
+	ID          int
+	Name        string
+	Description string
+	OwnerID     int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type ProjectResponse struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	OwnerID     int    `json:"owner_id"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type ProjectRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func ProjectFromProjectRequest(project *ProjectRequest) *Project {
+	return &Project{
+		Name:        project.Name,
+		Description: project.Description,
+	}
+}
+
+func ProjectToProjectResponse(project *Project) *ProjectResponse {
+	return &ProjectResponse{
+		ID:          project.ID,
+		Name:        project.Name,
+		Description: project.Description,
+		OwnerID:     project.OwnerID,
+		CreatedAt:   project.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:   project.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+type ProjectMember struct {
+	ProjectID int
+	UserID    int
+	Name      string
+	Role      string
+	CreatedAt time.Time
+}
+
+type ProjectMemberResponse struct {
+	UserID    int    `json:"user_id"`
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	CreatedAt string `json:"created_at"`
+}
+
+type ProjectMemberRequest struct {
+	UserID int    `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+func ProjectMemberFromRequest(member *ProjectMemberRequest) *ProjectMember {
+	return &ProjectMember{
+		UserID: member.UserID,
+		Role:   member.Role,
+	}
+}
+
+func ProjectMemberToResponse(member *ProjectMember) *ProjectMemberResponse {
+	return &ProjectMemberResponse{
+		UserID:    member.UserID,
+		Name:      member.Name,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
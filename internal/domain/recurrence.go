@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	InvalidRecurrence      = errors.New("Invalid recurrence")
+	InvalidOccurrenceScope = errors.New("Invalid occurrence scope")
+)
+
+// Which occurrences of a recurring task UpdateTask changes.
+const (
+	OccurrencesThis   = "this"
+	OccurrencesFuture = "future"
+)
+
+func ValidOccurrenceScope(scope string) bool {
+	return scope == OccurrencesThis || scope == OccurrencesFuture
+}
+
+// TaskSeries is the recurrence of a task. Occurrences are created from the
+// template of the series, see the task_series table.
+type TaskSeries struct {
+	ID       int
+	Rule     string
+	StartsAt time.Time
+	LastAt   time.Time
+	// NextAt is nil once the rule has ended.
+	NextAt *time.Time
+}
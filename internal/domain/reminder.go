@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+var InvalidReminder = errors.New("Invalid reminder")
+
+const (
+	MaxReminders = 5
+	// MaxReminderMinutes is 30 days.
+	MaxReminderMinutes = 30 * 24 * 60
+)
+
+// NormalizeReminders validates reminder offsets in minutes before the due
+// date, drops repeated ones and sorts them, earliest reminder first.
+func NormalizeReminders(minutes []int) ([]int, bool) {
+	result := make([]int, 0, len(minutes))
+	seen := make(map[int]bool)
+	for _, m := range minutes {
+		if m < 0 || m > MaxReminderMinutes {
+			return nil, false
+		}
+		if !seen[m] {
+			seen[m] = true
+			result = append(result, m)
+		}
+	}
+	if len(result) > MaxReminders {
+		return nil, false
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(result)))
+	return result, true
+}
+
+// Reminder is a reminder of a task that is due to be sent.
+type Reminder struct {
+	TaskID        int
+	MinutesBefore int
+	Title         string
+	DueDate       time.Time
+	// Recipient is the email address of the owner of the task, or their name
+	// when they have none. Email is empty then.
+	Recipient string
+	Email     string
+}
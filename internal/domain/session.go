@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var SessionNotFound = errors.New("Session not found")
+
+// Session is a login on one device, it lives as long as its refresh token
+// family. The ID is the family.
+type Session struct {
+	ID         string
+	UserID     int
+	IP         string
+	UserAgent  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	Current    bool
+}
+
+type SessionResponse struct {
+	ID         string `json:"id"`
+	IP         string `json:"ip"`
+	UserAgent  string `json:"user_agent"`
+	CreatedAt  string `json:"created_at"`
+	LastSeenAt string `json:"last_seen_at"`
+	Current    bool   `json:"current"`
+}
+
+func SessionToSessionResponse(session *Session) *SessionResponse {
+	return &SessionResponse{
+		ID:         session.ID,
+		IP:         session.IP,
+		UserAgent:  session.UserAgent,
+		CreatedAt:  session.CreatedAt.Format("2006-01-02 15:04:05"),
+		LastSeenAt: session.LastSeenAt.Format("2006-01-02 15:04:05"),
+		Current:    session.Current,
+	}
+}
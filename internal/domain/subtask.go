@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"math"
+)
+
+var InvalidChildrenMode = errors.New("Invalid children mode")
+
+// How DeleteTask treats the subtasks of a deleted task.
+const (
+	ChildrenCascade  = "cascade"
+	ChildrenReparent = "reparent"
+)
+
+func ValidChildrenMode(mode string) bool {
+	return mode == ChildrenCascade || mode == ChildrenReparent
+}
+
+type TaskParentRequest struct {
+	// ParentID 0 makes the task a top-level task.
+	ParentID int `json:"parent_id"`
+}
+
+// TaskNode is a task with its subtasks. Progress is 100 for a done leaf and
+// 0 for any other, and the mean progress of the children otherwise.
+type TaskNode struct {
+	Task     *Task
+	Children []*TaskNode
+	Progress float64
+}
+
+// BuildTaskTree arranges the descendants of root under it and computes the
+// progress of every node.
+func BuildTaskTree(root *Task, descendants []*Task) *TaskNode {
+	nodes := map[int]*TaskNode{root.ID: {Task: root}}
+	for _, task := range descendants {
+		nodes[task.ID] = &TaskNode{Task: task}
+	}
+	for _, task := range descendants {
+		if parent, ok := nodes[task.ParentID]; ok {
+			parent.Children = append(parent.Children, nodes[task.ID])
+		}
+	}
+
+	node := nodes[root.ID]
+	node.computeProgress()
+	return node
+}
+
+func (n *TaskNode) computeProgress() float64 {
+	if len(n.Children) == 0 {
+		n.Progress = 0
+		if n.Task.Done() {
+			n.Progress = 100
+		}
+		return n.Progress
+	}
+
+	var sum float64
+	for _, child := range n.Children {
+		sum += child.computeProgress()
+	}
+	n.Progress = sum / float64(len(n.Children))
+	return n.Progress
+}
+
+type TaskTreeResponse struct {
+	TaskResponse
+	Progress int                 `json:"progress"`
+	Subtasks []*TaskTreeResponse `json:"subtasks,omitempty"`
+}
+
+// TaskNodeToTreeResponse converts the node with its subtasks down to depth
+// levels, all of them if depth is negative.
+func TaskNodeToTreeResponse(node *TaskNode, depth int) *TaskTreeResponse {
+	resp := &TaskTreeResponse{
+		TaskResponse: *TaskToTaskResponse(node.Task),
+		Progress:     int(math.Round(node.Progress)),
+	}
+	if depth == 0 {
+		return resp
+	}
+
+	for _, child := range node.Children {
+		resp.Subtasks = append(resp.Subtasks, TaskNodeToTreeResponse(child, depth-1))
+	}
+	return resp
+}
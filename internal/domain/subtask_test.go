@@ -0,0 +1,63 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+func task(id, parentID int, category string) *domain.Task {
+	return &domain.Task{ID: id, ParentID: parentID, Status: category, StatusCategory: category}
+}
+
+func TestBuildTaskTree(t *testing.T) {
+	root := domain.BuildTaskTree(task(1, 0, domain.StatusCategoryInProgress), []*domain.Task{
+		task(2, 1, domain.StatusCategoryDone),
+		task(3, 1, domain.StatusCategoryTodo),
+		task(4, 3, domain.StatusCategoryDone),
+		task(5, 3, domain.StatusCategoryInProgress),
+		task(6, 5, domain.StatusCategoryTodo),
+	})
+
+	assert.Equal(t, 1, root.Task.ID)
+	if assert.Len(t, root.Children, 2) {
+		assert.Equal(t, 2, root.Children[0].Task.ID)
+		assert.Equal(t, 100.0, root.Children[0].Progress)
+
+		// Progress comes from the subtasks, not from the status of task 3.
+		node := root.Children[1]
+		assert.Equal(t, 3, node.Task.ID)
+		assert.Equal(t, 50.0, node.Progress)
+		if assert.Len(t, node.Children, 2) {
+			assert.Equal(t, 0.0, node.Children[1].Progress)
+		}
+	}
+	assert.Equal(t, 75.0, root.Progress)
+}
+
+func TestBuildTaskTreeLeaf(t *testing.T) {
+	assert.Equal(t, 0.0, domain.BuildTaskTree(task(1, 0, domain.StatusCategoryInProgress), nil).Progress)
+	assert.Equal(t, 100.0, domain.BuildTaskTree(task(1, 0, domain.StatusCategoryDone), nil).Progress)
+}
+
+func TestTaskNodeToTreeResponseDepth(t *testing.T) {
+	root := domain.BuildTaskTree(task(1, 0, domain.StatusCategoryTodo), []*domain.Task{
+		task(2, 1, domain.StatusCategoryDone),
+		task(3, 1, domain.StatusCategoryTodo),
+		task(4, 3, domain.StatusCategoryDone),
+		task(5, 3, domain.StatusCategoryTodo),
+	})
+
+	resp := domain.TaskNodeToTreeResponse(root, 1)
+	assert.Equal(t, 75, resp.Progress)
+	if assert.Len(t, resp.Subtasks, 2) {
+		assert.Equal(t, 50, resp.Subtasks[1].Progress)
+		assert.Empty(t, resp.Subtasks[1].Subtasks)
+	}
+
+	resp = domain.TaskNodeToTreeResponse(root, -1)
+	if assert.Len(t, resp.Subtasks, 2) {
+		assert.Len(t, resp.Subtasks[1].Subtasks, 2)
+	}
+}
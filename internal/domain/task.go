@@ -1,9 +1,21 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	TaskNotFound      = errors.New("Task not found")
+	InvalidParentTask = errors.New("Invalid parent task")
+)
 
 type Task struct {
 	ID          int
+	UserID      int
+	ProjectID   int
+	ParentID    int
 	Title       string
 	Description string
 	Status      string
@@ -11,48 +23,147 @@ type Task struct {
 	Due_date    time.Time
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	// StatusCategory is the category of Status in the workflow of the task.
+	StatusCategory string
+	// AutoComplete marks the task done once all of its subtasks are done.
+	AutoComplete bool
+	Labels       []*Label
+	CommentCount int
+	// Comments are only loaded for export.
+	Comments []*Comment
+	// DeletedAt is set for tasks in the trash.
+	DeletedAt *time.Time
+	// SeriesID is set for occurrences of a recurring task, OccursAt is their
+	// slot in the series. Recurrence and NextOccurrence describe the series.
+	SeriesID       int
+	OccursAt       time.Time
+	Recurrence     string
+	NextOccurrence *time.Time
+	// Reminders are sent the given minutes before the due date.
+	Reminders []int
+}
+
+func (t *Task) Done() bool {
+	return t.StatusCategory == StatusCategoryDone
 }
 
 type TaskResponse struct {
 	ID          int    `json:"id"`
+	ProjectID   int    `json:"project_id,omitempty"`
+	ParentID    int    `json:"parent_id,omitempty"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	Status      string `json:"status"`
-	Priority    string `json:"priority"`
-	Due_date    string `json:"due_date"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	// StatusCategory is the category of the status in the workflow of the task.
+	StatusCategory string `json:"status_category,omitempty"`
+	Priority       string `json:"priority"`
+	Due_date       string `json:"due_date"`
+	AutoComplete   bool   `json:"auto_complete"`
+	// Labels decode as LabelRequest, so exported tasks can be imported again.
+	Labels       []*LabelResponse   `json:"labels"`
+	CommentCount int                `json:"comment_count"`
+	Comments     []*CommentResponse `json:"comments,omitempty"`
+	CreatedAt    string             `json:"created_at"`
+	UpdatedAt    string             `json:"updated_at"`
+	DeletedAt    string             `json:"deleted_at,omitempty"`
+	// Recurrence is an RFC 5545 RRULE. The next occurrence of the series is
+	// created at NextOccurrence, or earlier when the latest one is done.
+	SeriesID       int    `json:"series_id,omitempty"`
+	Recurrence     string `json:"recurrence,omitempty"`
+	NextOccurrence string `json:"next_occurrence,omitempty"`
+	Reminders      []int  `json:"reminders,omitempty"`
 }
 
 type TaskRequest struct {
+	ProjectID int `json:"project_id,omitempty"`
+	// ParentID is only read on creation, subtasks are moved with the parent endpoint.
+	ParentID    int    `json:"parent_id,omitempty"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
-	Status      string `json:"status"`
-	Priority    string `json:"priority"`
-	Due_date    string `json:"due_date"`
+	// Status must belong to the workflow of the task and changes follow its
+	// transitions. New tasks start in the first todo status when it is empty,
+	// updates keep the status.
+	Status       string `json:"status"`
+	Priority     string `json:"priority"`
+	Due_date     string `json:"due_date"`
+	AutoComplete bool   `json:"auto_complete"`
+	// Labels replace the labels of the task. Labels missing in the scope of
+	// the task are created.
+	Labels []*LabelRequest `json:"labels,omitempty"`
+	// Recurrence makes the task recurring, starting at its due date. For an
+	// occurrence it is only read when all future occurrences are changed.
+	Recurrence string `json:"recurrence,omitempty"`
+	// Reminders replace the reminders of the task, in minutes before its due
+	// date.
+	Reminders []int `json:"reminders,omitempty"`
 }
 
 func TaskFromTaskRequest(task *TaskRequest) *Task {
 	parsedTime, _ := time.Parse("2006-01-02 15:04:05", task.Due_date)
 	return &Task{
-		Title:       task.Title,
-		Description: task.Description,
-		Status:      task.Status,
-		Priority:    task.Priority,
-		Due_date:    parsedTime,
+		ProjectID:    task.ProjectID,
+		ParentID:     task.ParentID,
+		Title:        task.Title,
+		Description:  task.Description,
+		Status:       task.Status,
+		Priority:     task.Priority,
+		Due_date:     parsedTime,
+		AutoComplete: task.AutoComplete,
+		Labels:       labelsFromLabelRequests(task.Labels),
+		Recurrence:   task.Recurrence,
+		Reminders:    task.Reminders,
+	}
+}
+
+func labelsFromLabelRequests(labels []*LabelRequest) []*Label {
+	result := make([]*Label, 0, len(labels))
+	for _, label := range labels {
+		if label != nil {
+			result = append(result, LabelFromLabelRequest(label))
+		}
 	}
+	return result
 }
 
 func TaskToTaskResponse(task *Task) *TaskResponse {
+	labels := make([]*LabelResponse, 0, len(task.Labels))
+	for _, label := range task.Labels {
+		labels = append(labels, LabelToLabelResponse(label))
+	}
+	var comments []*CommentResponse
+	for _, comment := range task.Comments {
+		comments = append(comments, CommentToCommentResponse(comment))
+	}
+
+	var deletedAt, nextOccurrence string
+	if task.DeletedAt != nil {
+		deletedAt = task.DeletedAt.Format("2006-01-02 15:04:05")
+	}
+	if task.NextOccurrence != nil {
+		nextOccurrence = task.NextOccurrence.Format("2006-01-02 15:04:05")
+	}
+
 	return &TaskResponse{
-		ID:          task.ID,
-		Title:       task.Title,
-		Description: task.Description,
-		Status:      task.Status,
-		Priority:    task.Priority,
-		Due_date:    task.Due_date.Format("2006-01-02 15:04:05"),
-		CreatedAt:   task.CreatedAt.Format("2006-01-02 15:04:05"),
-		UpdatedAt:   task.UpdatedAt.Format("2006-01-02 15:04:05"),
+		ID:             task.ID,
+		ProjectID:      task.ProjectID,
+		ParentID:       task.ParentID,
+		Title:          task.Title,
+		Description:    task.Description,
+		Status:         task.Status,
+		StatusCategory: task.StatusCategory,
+		Priority:       task.Priority,
+		Due_date:       task.Due_date.Format("2006-01-02 15:04:05"),
+		AutoComplete:   task.AutoComplete,
+		Labels:         labels,
+		CommentCount:   task.CommentCount,
+		Comments:       comments,
+		CreatedAt:      task.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:      task.UpdatedAt.Format("2006-01-02 15:04:05"),
+		DeletedAt:      deletedAt,
+		SeriesID:       task.SeriesID,
+		Recurrence:     task.Recurrence,
+		NextOccurrence: nextOccurrence,
+		Reminders:      task.Reminders,
 	}
 }
 
@@ -60,30 +171,64 @@ func TaskFromTaskResponse(task *TaskResponse) *Task {
 	parsedTime, _ := time.Parse("2006-01-02 15:04:05", task.Due_date)
 	createdAt, _ := time.Parse("2006-01-02 15:04:05", task.CreatedAt)
 	updatedAt, _ := time.Parse("2006-01-02 15:04:05", task.UpdatedAt)
+	labels := make([]*Label, 0, len(task.Labels))
+	for _, label := range task.Labels {
+		labels = append(labels, &Label{ID: label.ID, ProjectID: label.ProjectID, Name: label.Name, Color: label.Color})
+	}
+
 	return &Task{
-		ID:          task.ID,
-		Title:       task.Title,
-		Description: task.Description,
-		Status:      task.Status,
-		Priority:    task.Priority,
-		Due_date:    parsedTime,
-		CreatedAt:   createdAt,
-		UpdatedAt:   updatedAt,
+		ID:           task.ID,
+		ProjectID:    task.ProjectID,
+		ParentID:     task.ParentID,
+		Title:        task.Title,
+		Description:  task.Description,
+		Status:       task.Status,
+		Priority:     task.Priority,
+		Due_date:     parsedTime,
+		AutoComplete: task.AutoComplete,
+		Labels:       labels,
+		CommentCount: task.CommentCount,
+		CreatedAt:    createdAt,
+		UpdatedAt:    updatedAt,
+		Reminders:    task.Reminders,
 	}
 }
 
 type TaskFilter struct {
-	Status   string
+	UserID    int
+	ProjectID int
+	Status    string
+	// Category keeps tasks whose status is in the status category.
+	Category string
 	SortBy   string
 	Priority string
 	Name     string
+	// Labels keeps tasks carrying any or all (LabelMatch) of the label names.
+	Labels     []string
+	LabelMatch string
+}
+
+// TaskScope selects the tasks analytics and export operate on: a single
+// project when ProjectID is set, otherwise every task owned by UserID.
+type TaskScope struct {
+	UserID    int
+	ProjectID int
+}
+
+func (s TaskScope) Key() string {
+	if s.ProjectID != 0 {
+		return fmt.Sprintf("project:%d", s.ProjectID)
+	}
+	return fmt.Sprintf("user:%d", s.UserID)
 }
 
+// Analyse counts the tasks by status category, and by status in Statuses.
 type Analyse struct {
-	Done        int     `json:"done"`
-	InProgress  int     `json:"in_progress"`
-	Pending     int     `json:"pending"`
-	AverageTime float64 `json:"average_time"`
+	Todo        int            `json:"todo"`
+	InProgress  int            `json:"in_progress"`
+	Done        int            `json:"done"`
+	Statuses    map[string]int `json:"statuses"`
+	AverageTime float64        `json:"average_time"`
 	Weekly      WeeklyReport
 }
 
@@ -1,18 +1,85 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
-var UserNotFound = errors.New("User not found")
+var (
+	UserNotFound = errors.New("User not found")
+	Unauthorized = errors.New("Unauthorized")
+	InvalidToken = errors.New("Invalid token")
+	TokenReused  = errors.New("Refresh token reused")
+
+	UserDisabled          = errors.New("User disabled")
+	PasswordResetRequired = errors.New("Password reset required")
+	InvalidUserRole       = errors.New("Invalid user role")
+	TooManyAttempts       = errors.New("Too many attempts")
+	UserAlreadyExists     = errors.New("User already exists")
+)
+
+// LockedOutError is returned while logins to an account are locked after
+// repeated failures.
+type LockedOutError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedOutError) Error() string {
+	return TooManyAttempts.Error()
+}
+
+func (e *LockedOutError) Unwrap() error {
+	return TooManyAttempts
+}
+
+const (
+	UserRoleAdmin    = "admin"
+	UserRoleMember   = "member"
+	UserRoleReadOnly = "readonly"
+)
+
+var userRoleRank = map[string]int{
+	UserRoleReadOnly: 1,
+	UserRoleMember:   2,
+	UserRoleAdmin:    3,
+}
+
+func ValidUserRole(role string) bool {
+	_, ok := userRoleRank[role]
+	return ok
+}
+
+// UserRoleAtLeast reports whether role grants at least the rights of required.
+func UserRoleAtLeast(role, required string) bool {
+	return ValidUserRole(role) && userRoleRank[role] >= userRoleRank[required]
+}
 
 type User struct {
-	ID       int
-	Name     string
-	Password string
+	ID                    int
+	Name                  string
+	Password              string
+	Role                  string
+	Disabled              bool
+	PasswordResetRequired bool
+	MFAEnabled            bool
+	DisplayName           string
+	Email                 string
+	Timezone              string
+	Locale                string
+	CreatedAt             time.Time
 }
 
 type UserResponse struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID                    int    `json:"id"`
+	Name                  string `json:"name"`
+	Role                  string `json:"role,omitempty"`
+	Disabled              bool   `json:"disabled"`
+	PasswordResetRequired bool   `json:"password_reset_required"`
+	MFAEnabled            bool   `json:"mfa_enabled"`
+}
+
+type UserRoleRequest struct {
+	Role string `json:"role"`
 }
 
 type UserRequest struct {
@@ -22,8 +89,12 @@ type UserRequest struct {
 
 func UserToUserResponse(user *User) *UserResponse {
 	return &UserResponse{
-		ID:   user.ID,
-		Name: user.Name,
+		ID:                    user.ID,
+		Name:                  user.Name,
+		Role:                  user.Role,
+		Disabled:              user.Disabled,
+		PasswordResetRequired: user.PasswordResetRequired,
+		MFAEnabled:            user.MFAEnabled,
 	}
 }
 
@@ -33,3 +104,42 @@ func UserRequestToUser(user *UserRequest) *User {
 		Password: user.Password,
 	}
 }
+
+// Tokens holds either a token pair or, when the second factor is still
+// missing, only MFAToken.
+type Tokens struct {
+	AccessToken      string
+	RefreshToken     string
+	AccessExpiresAt  time.Time
+	RefreshExpiresAt time.Time
+	MFAToken         string
+	MFAExpiresAt     time.Time
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type TokensResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+func TokensToTokensResponse(tokens *Tokens) *TokensResponse {
+	return &TokensResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(time.Until(tokens.AccessExpiresAt).Seconds()),
+	}
+}
+
+func TokensToMFAChallengeResponse(tokens *Tokens) *MFAChallengeResponse {
+	return &MFAChallengeResponse{
+		MFARequired: true,
+		MFAToken:    tokens.MFAToken,
+		ExpiresIn:   int(time.Until(tokens.MFAExpiresAt).Seconds()),
+	}
+}
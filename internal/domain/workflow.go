@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	InvalidWorkflow       = errors.New("Invalid workflow")
+	InvalidStatus         = errors.New("Invalid status")
+	InvalidStatusCategory = errors.New("Invalid status category")
+	InvalidTransition     = errors.New("Status transition not allowed")
+	StatusInUse           = errors.New("Status in use")
+)
+
+// Categories group the statuses of all workflows for analytics and for the
+// rules that depend on whether a task is open or done.
+const (
+	StatusCategoryTodo       = "todo"
+	StatusCategoryInProgress = "in_progress"
+	StatusCategoryDone       = "done"
+)
+
+const (
+	maxStatuses         = 20
+	maxStatusNameLength = 50
+)
+
+func ValidStatusCategory(category string) bool {
+	return category == StatusCategoryTodo || category == StatusCategoryInProgress || category == StatusCategoryDone
+}
+
+type WorkflowStatus struct {
+	Name     string
+	Category string
+}
+
+type WorkflowTransition struct {
+	From string
+	To   string
+}
+
+// Workflow is the statuses of the tasks of a project and the changes allowed
+// between them. ProjectID 0 is the default workflow, used for personal tasks
+// and by projects that are not Custom.
+type Workflow struct {
+	ProjectID int
+	Custom    bool
+	// Statuses are in order, new tasks start in the first todo status.
+	Statuses    []*WorkflowStatus
+	Transitions []*WorkflowTransition
+}
+
+func (w *Workflow) Status(name string) *WorkflowStatus {
+	for _, status := range w.Statuses {
+		if status.Name == name {
+			return status
+		}
+	}
+	return nil
+}
+
+// Initial returns the first status of the todo category.
+func (w *Workflow) Initial() string {
+	for _, status := range w.Statuses {
+		if status.Category == StatusCategoryTodo {
+			return status.Name
+		}
+	}
+	return ""
+}
+
+// CanTransition reports whether a task may change from one status to the
+// other. Keeping the status is always allowed.
+func (w *Workflow) CanTransition(from, to string) bool {
+	if from == to {
+		return true
+	}
+	for _, transition := range w.Transitions {
+		if transition.From == from && transition.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeWorkflow trims the status names, drops repeated transitions and
+// reports whether the workflow is valid: unique status names, at least one
+// todo and one done status, and transitions between distinct known statuses.
+func NormalizeWorkflow(w *Workflow) bool {
+	if len(w.Statuses) == 0 || len(w.Statuses) > maxStatuses {
+		return false
+	}
+
+	seen := make(map[string]bool)
+	categories := make(map[string]bool)
+	for _, status := range w.Statuses {
+		if status == nil {
+			return false
+		}
+		status.Name = strings.TrimSpace(status.Name)
+		if status.Name == "" || utf8.RuneCountInString(status.Name) > maxStatusNameLength || seen[status.Name] {
+			return false
+		}
+		if !ValidStatusCategory(status.Category) {
+			return false
+		}
+		seen[status.Name] = true
+		categories[status.Category] = true
+	}
+	if !categories[StatusCategoryTodo] || !categories[StatusCategoryDone] {
+		return false
+	}
+
+	transitions := make([]*WorkflowTransition, 0, len(w.Transitions))
+	seenTransitions := make(map[WorkflowTransition]bool)
+	for _, transition := range w.Transitions {
+		if transition == nil {
+			return false
+		}
+		transition.From, transition.To = strings.TrimSpace(transition.From), strings.TrimSpace(transition.To)
+		if !seen[transition.From] || !seen[transition.To] || transition.From == transition.To {
+			return false
+		}
+		if !seenTransitions[*transition] {
+			seenTransitions[*transition] = true
+			transitions = append(transitions, transition)
+		}
+	}
+	w.Transitions = transitions
+
+	return true
+}
+
+type WorkflowStatusRequest struct {
+	Name string `json:"name"`
+	// Category is one of todo, in_progress or done.
+	Category string `json:"category"`
+}
+
+type WorkflowTransitionRequest struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type WorkflowRequest struct {
+	Statuses    []*WorkflowStatusRequest     `json:"statuses"`
+	Transitions []*WorkflowTransitionRequest `json:"transitions"`
+}
+
+type WorkflowStatusResponse struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
+
+type WorkflowTransitionResponse struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type WorkflowResponse struct {
+	ProjectID   int                           `json:"project_id,omitempty"`
+	Custom      bool                          `json:"custom"`
+	Statuses    []*WorkflowStatusResponse     `json:"statuses"`
+	Transitions []*WorkflowTransitionResponse `json:"transitions"`
+}
+
+func WorkflowFromWorkflowRequest(workflow *WorkflowRequest) *Workflow {
+	statuses := make([]*WorkflowStatus, 0, len(workflow.Statuses))
+	for _, status := range workflow.Statuses {
+		if status == nil {
+			statuses = append(statuses, nil)
+			continue
+		}
+		statuses = append(statuses, &WorkflowStatus{Name: status.Name, Category: status.Category})
+	}
+
+	transitions := make([]*WorkflowTransition, 0, len(workflow.Transitions))
+	for _, transition := range workflow.Transitions {
+		if transition == nil {
+			transitions = append(transitions, nil)
+			continue
+		}
+		transitions = append(transitions, &WorkflowTransition{From: transition.From, To: transition.To})
+	}
+
+	return &Workflow{Statuses: statuses, Transitions: transitions}
+}
+
+func WorkflowToWorkflowResponse(workflow *Workflow) *WorkflowResponse {
+	statuses := make([]*WorkflowStatusResponse, 0, len(workflow.Statuses))
+	for _, status := range workflow.Statuses {
+		statuses = append(statuses, &WorkflowStatusResponse{Name: status.Name, Category: status.Category})
+	}
+
+	transitions := make([]*WorkflowTransitionResponse, 0, len(workflow.Transitions))
+	for _, transition := range workflow.Transitions {
+		transitions = append(transitions, &WorkflowTransitionResponse{From: transition.From, To: transition.To})
+	}
+
+	return &WorkflowResponse{
+		ProjectID:   workflow.ProjectID,
+		Custom:      workflow.Custom,
+		Statuses:    statuses,
+		Transitions: transitions,
+	}
+}
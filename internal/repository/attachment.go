@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+type AttachmentRepository struct {
+	DataBase *pgxpool.Pool
+}
+
+func NewAttachmentRepository(db *pgxpool.Pool) AttachmentRepositoryInterface {
+	return &AttachmentRepository{DataBase: db}
+}
+
+const attachmentColumns = `id, task_id, COALESCE(user_id, 0), name, content_type, size, checksum, blob_key, created_at`
+
+func scanAttachment(row pgx.Row, attachment *domain.Attachment) error {
+	return row.Scan(&attachment.ID, &attachment.TaskID, &attachment.UserID, &attachment.Name, &attachment.ContentType, &attachment.Size, &attachment.Checksum, &attachment.BlobKey, &attachment.CreatedAt)
+}
+
+func (r *AttachmentRepository) GetAttachments(ctx context.Context, taskID int) ([]*domain.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE task_id = $1 ORDER BY id`
+
+	rows, err := r.DataBase.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	attachments := make([]*domain.Attachment, 0)
+	for rows.Next() {
+		attachment := &domain.Attachment{}
+		if err := scanAttachment(rows, attachment); err != nil {
+			return nil, err
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, rows.Err()
+}
+
+func (r *AttachmentRepository) GetAttachment(ctx context.Context, taskID, attachmentID int) (*domain.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE id = $1 AND task_id = $2`
+
+	attachment := &domain.Attachment{}
+	if err := scanAttachment(r.DataBase.QueryRow(ctx, query, attachmentID, taskID), attachment); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.AttachmentNotFound
+		}
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (r *AttachmentRepository) CreateAttachment(ctx context.Context, attachment *domain.Attachment) (*domain.Attachment, error) {
+	query := `INSERT INTO attachments (task_id, user_id, name, content_type, size, checksum, blob_key)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING ` + attachmentColumns
+
+	err := scanAttachment(r.DataBase.QueryRow(ctx, query, attachment.TaskID, attachment.UserID, attachment.Name, attachment.ContentType, attachment.Size, attachment.Checksum, attachment.BlobKey), attachment)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// DeleteAttachment removes the attachment, its blob is queued as orphaned.
+func (r *AttachmentRepository) DeleteAttachment(ctx context.Context, taskID, attachmentID int) error {
+	tag, err := r.DataBase.Exec(ctx, `DELETE FROM attachments WHERE id = $1 AND task_id = $2`, attachmentID, taskID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.AttachmentNotFound
+	}
+
+	return nil
+}
+
+// GetOrphanedBlobs returns up to limit keys of blobs no attachment refers to.
+func (r *AttachmentRepository) GetOrphanedBlobs(ctx context.Context, limit int) ([]string, error) {
+	rows, err := r.DataBase.Query(ctx, `SELECT blob_key FROM orphaned_blobs ORDER BY created_at LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	keys := make([]string, 0)
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+func (r *AttachmentRepository) RemoveOrphanedBlobs(ctx context.Context, keys []string) error {
+	_, err := r.DataBase.Exec(ctx, `DELETE FROM orphaned_blobs WHERE blob_key = ANY($1)`, keys)
+	return err
+}
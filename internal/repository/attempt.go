@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// loginFailureWindow is how long failed attempts are remembered.
+	loginFailureWindow = 15 * time.Minute
+	// loginLockoutMax caps the lockout duration and is also how long the
+	// lockout level is remembered before it starts over.
+	loginLockoutMax = 24 * time.Hour
+)
+
+type LoginAttemptRepository struct {
+	Cache *redis.Client
+}
+
+func NewLoginAttemptRepository(cache *redis.Client) LoginAttemptRepositoryInterface {
+	return &LoginAttemptRepository{Cache: cache}
+}
+
+func loginFailuresKey(name string) string {
+	return "login:failures:" + name
+}
+
+func loginLevelKey(name string) string {
+	return "login:level:" + name
+}
+
+func loginLockKey(name string) string {
+	return "login:lock:" + name
+}
+
+// failureScript counts a failed login. Once maxFailures is reached the
+// account is locked, each lockout twice as long as the previous one.
+var failureScript = redis.NewScript(`
+local failures = redis.call("INCR", KEYS[1])
+redis.call("PEXPIRE", KEYS[1], ARGV[3])
+if failures < tonumber(ARGV[1]) then
+	return 0
+end
+redis.call("DEL", KEYS[1])
+local level = redis.call("INCR", KEYS[2])
+redis.call("PEXPIRE", KEYS[2], ARGV[4])
+local lockout = math.min(tonumber(ARGV[2]) * 2 ^ (level - 1), tonumber(ARGV[4]))
+redis.call("SET", KEYS[3], level, "PX", math.floor(lockout))
+return math.floor(lockout)
+`)
+
+// GetLockout returns how long logins to the account stay locked, zero if
+// they are not.
+func (r *LoginAttemptRepository) GetLockout(ctx context.Context, name string) (time.Duration, error) {
+	ttl, err := r.Cache.PTTL(ctx, loginLockKey(name)).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// RecordLoginFailure returns the lockout started by this failure, zero if
+// the account is not locked yet.
+func (r *LoginAttemptRepository) RecordLoginFailure(ctx context.Context, name string, maxFailures int, lockout time.Duration) (time.Duration, error) {
+	keys := []string{loginFailuresKey(name), loginLevelKey(name), loginLockKey(name)}
+	ms, err := failureScript.Run(ctx, r.Cache, keys, maxFailures, lockout.Milliseconds(), loginFailureWindow.Milliseconds(), loginLockoutMax.Milliseconds()).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+func (r *LoginAttemptRepository) ResetLoginFailures(ctx context.Context, name string) error {
+	return r.Cache.Del(ctx, loginFailuresKey(name), loginLevelKey(name)).Err()
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+type CommentRepository struct {
+	DataBase *pgxpool.Pool
+}
+
+func NewCommentRepository(db *pgxpool.Pool) CommentRepositoryInterface {
+	return &CommentRepository{DataBase: db}
+}
+
+const commentColumns = `c.id, c.task_id, COALESCE(c.user_id, 0), COALESCE(u.name, ''), COALESCE(c.parent_id, 0), c.body, c.created_at, c.updated_at, c.deleted_at IS NOT NULL`
+
+const commentsWithAuthor = `comments c LEFT JOIN users u ON u.id = c.user_id`
+
+func scanComment(row pgx.Row, comment *domain.Comment) error {
+	return row.Scan(&comment.ID, &comment.TaskID, &comment.UserID, &comment.Author, &comment.ParentID, &comment.Body, &comment.CreatedAt, &comment.UpdatedAt, &comment.Deleted)
+}
+
+func queryComments(ctx context.Context, db *pgxpool.Pool, query string, args ...any) ([]*domain.Comment, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	comments := make([]*domain.Comment, 0)
+	for rows.Next() {
+		comment := &domain.Comment{}
+		if err := scanComment(rows, comment); err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
+// GetComments returns a page of the top-level comments of the task, oldest
+// first, together with all of their replies, and the number of top-level comments.
+func (r *CommentRepository) GetComments(ctx context.Context, taskID int, page domain.Page) ([]*domain.Comment, int, error) {
+	var total int
+	query := `SELECT COUNT(*) FROM comments WHERE task_id = $1 AND parent_id IS NULL`
+	if err := r.DataBase.QueryRow(ctx, query, taskID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query = `WITH RECURSIVE roots AS (
+		SELECT id FROM comments WHERE task_id = $1 AND parent_id IS NULL ORDER BY id LIMIT $2 OFFSET $3
+	), thread AS (
+		SELECT id FROM roots
+		UNION
+		SELECT c.id FROM comments c JOIN thread t ON c.parent_id = t.id
+	)
+	SELECT ` + commentColumns + ` FROM ` + commentsWithAuthor + ` WHERE c.id IN (SELECT id FROM thread) ORDER BY c.id`
+
+	comments, err := queryComments(ctx, r.DataBase, query, taskID, page.Limit, page.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
+
+func (r *CommentRepository) GetComment(ctx context.Context, commentID int) (*domain.Comment, error) {
+	query := `SELECT ` + commentColumns + ` FROM ` + commentsWithAuthor + ` WHERE c.id = $1`
+
+	comment := &domain.Comment{}
+	if err := scanComment(r.DataBase.QueryRow(ctx, query, commentID), comment); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.CommentNotFound
+		}
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// CreateComment adds the comment, a reply only to a live comment of the same task.
+func (r *CommentRepository) CreateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+	query := `INSERT INTO comments (task_id, user_id, parent_id, body)
+	SELECT $1::int, $2::int, NULLIF($3::int, 0), $4::text
+	WHERE $3 = 0 OR EXISTS (SELECT 1 FROM comments WHERE id = $3 AND task_id = $1 AND deleted_at IS NULL)
+	RETURNING id`
+
+	var id int
+	if err := r.DataBase.QueryRow(ctx, query, comment.TaskID, comment.UserID, comment.ParentID, comment.Body).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.InvalidParentComment
+		}
+		return nil, err
+	}
+
+	return r.GetComment(ctx, id)
+}
+
+func (r *CommentRepository) UpdateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+	query := `UPDATE comments SET body = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL`
+
+	tag, err := r.DataBase.Exec(ctx, query, comment.ID, comment.Body)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, domain.CommentNotFound
+	}
+
+	return r.GetComment(ctx, comment.ID)
+}
+
+// DeleteComment removes the comment, or only blanks it while it has replies.
+// Blanked ancestors left without replies are removed as well.
+func (r *CommentRepository) DeleteComment(ctx context.Context, commentID int) error {
+	tx, err := r.DataBase.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	query := `UPDATE comments SET body = '', deleted_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND deleted_at IS NULL AND EXISTS (SELECT 1 FROM comments WHERE parent_id = $1)`
+	tag, err := tx.Exec(ctx, query, commentID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		query = `DELETE FROM comments WHERE id = $1 AND deleted_at IS NULL RETURNING COALESCE(parent_id, 0)`
+		parentID := 0
+		if err := tx.QueryRow(ctx, query, commentID).Scan(&parentID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.CommentNotFound
+			}
+			return err
+		}
+
+		query = `DELETE FROM comments WHERE id = $1 AND deleted_at IS NOT NULL
+		AND NOT EXISTS (SELECT 1 FROM comments WHERE parent_id = $1)
+		RETURNING COALESCE(parent_id, 0)`
+		for parentID != 0 {
+			if err := tx.QueryRow(ctx, query, parentID).Scan(&parentID); err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					break
+				}
+				return err
+			}
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// loadCommentCounts fills in the number of live comments of the tasks.
+func (r *TaskRepository) loadCommentCounts(ctx context.Context, tasks []*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(tasks))
+	byID := make(map[int]*domain.Task, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+		byID[task.ID] = task
+	}
+
+	query := `SELECT task_id, COUNT(*) FROM comments WHERE task_id = ANY($1) AND deleted_at IS NULL GROUP BY task_id`
+	rows, err := r.DataBase.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID, count int
+		if err := rows.Scan(&taskID, &count); err != nil {
+			return err
+		}
+
+		if task, ok := byID[taskID]; ok {
+			task.CommentCount = count
+		}
+	}
+
+	return rows.Err()
+}
+
+// loadComments fills in the comment threads of the tasks.
+func (r *TaskRepository) loadComments(ctx context.Context, tasks []*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+
+	query := `SELECT ` + commentColumns + ` FROM ` + commentsWithAuthor + ` WHERE c.task_id = ANY($1) ORDER BY c.id`
+	comments, err := queryComments(ctx, r.DataBase, query, ids)
+	if err != nil {
+		return err
+	}
+
+	byTask := make(map[int][]*domain.Comment)
+	for _, comment := range comments {
+		byTask[comment.TaskID] = append(byTask[comment.TaskID], comment)
+	}
+	for _, task := range tasks {
+		task.Comments = domain.BuildCommentThreads(byTask[task.ID])
+	}
+
+	return nil
+}
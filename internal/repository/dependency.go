@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+// blockersOf walks the dependencies of $1 transitively. It includes tasks in
+// the trash, which would close a cycle once restored.
+const blockersOf = `WITH RECURSIVE deps AS (
+	SELECT task_id, blocker_id FROM task_dependencies WHERE task_id = $1
+	UNION
+	SELECT d.task_id, d.blocker_id FROM task_dependencies d JOIN deps ON d.task_id = deps.blocker_id
+)`
+
+// liveBlockersOf is blockersOf without the tasks in the trash.
+const liveBlockersOf = `WITH RECURSIVE deps AS (
+	SELECT d.task_id, d.blocker_id FROM task_dependencies d JOIN tasks b ON b.id = d.blocker_id
+	WHERE d.task_id = $1 AND b.deleted_at IS NULL
+	UNION
+	SELECT d.task_id, d.blocker_id FROM task_dependencies d JOIN deps ON d.task_id = deps.blocker_id JOIN tasks b ON b.id = d.blocker_id
+	WHERE b.deleted_at IS NULL
+)`
+
+func (r *TaskRepository) GetBlockers(ctx context.Context, taskID int) ([]*domain.Task, error) {
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE id IN (SELECT blocker_id FROM task_dependencies WHERE task_id = $1) AND ` + liveTask + ` ORDER BY id`
+
+	rows, err := r.DataBase.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	tasks := make([]*domain.Task, 0)
+	for rows.Next() {
+		task := &domain.Task{}
+		if err := scanTask(rows, task); err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tasks, r.loadDetails(ctx, tasks)
+}
+
+// AddDependency adds the edge unless the blocker already depends on the
+// task, directly or transitively. Inserts are serialized so that two
+// concurrent edges cannot close a cycle together.
+func (r *TaskRepository) AddDependency(ctx context.Context, userID int, dep domain.TaskDependency) error {
+	tx, err := r.DataBase.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err := tx.Exec(ctx, `LOCK TABLE task_dependencies IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return err
+	}
+
+	var editable bool
+	query := `SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1 AND ` + liveTask + ` AND ` + fmt.Sprintf(editableByUser, 2) + `)`
+	if err := tx.QueryRow(ctx, query, dep.TaskID, userID).Scan(&editable); err != nil {
+		return err
+	}
+	if !editable {
+		return domain.TaskNotFound
+	}
+
+	var cycle bool
+	query = blockersOf + ` SELECT EXISTS (SELECT 1 FROM deps WHERE blocker_id = $2)`
+	if err := tx.QueryRow(ctx, query, dep.BlockerID, dep.TaskID).Scan(&cycle); err != nil {
+		return err
+	}
+	if cycle || dep.TaskID == dep.BlockerID {
+		return domain.DependencyCycle
+	}
+
+	query = `INSERT INTO task_dependencies (task_id, blocker_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	if _, err := tx.Exec(ctx, query, dep.TaskID, dep.BlockerID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *TaskRepository) RemoveDependency(ctx context.Context, userID int, dep domain.TaskDependency) error {
+	query := `DELETE FROM task_dependencies WHERE task_id = $1 AND blocker_id = $2
+	AND task_id IN (SELECT id FROM tasks WHERE ` + fmt.Sprintf(editableByUser, 3) + `)`
+
+	tag, err := r.DataBase.Exec(ctx, query, dep.TaskID, dep.BlockerID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.DependencyNotFound
+	}
+	return nil
+}
+
+func (r *TaskRepository) CountOpenBlockers(ctx context.Context, taskID int) (int, error) {
+	query := `SELECT COUNT(*) FROM task_dependencies d JOIN tasks t ON t.id = d.blocker_id
+	WHERE d.task_id = $1 AND t.deleted_at IS NULL AND ` + openTask("t.")
+
+	var count int
+	if err := r.DataBase.QueryRow(ctx, query, taskID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetDependencyGraph returns the task with its transitive blockers and the
+// edges between them.
+func (r *TaskRepository) GetDependencyGraph(ctx context.Context, taskID int) ([]*domain.Task, []domain.TaskDependency, error) {
+	rows, err := r.DataBase.Query(ctx, liveBlockersOf+` SELECT task_id, blocker_id FROM deps`, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := []int{taskID}
+	deps := make([]domain.TaskDependency, 0)
+	for rows.Next() {
+		var dep domain.TaskDependency
+		if err := rows.Scan(&dep.TaskID, &dep.BlockerID); err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		deps = append(deps, dep)
+		ids = append(ids, dep.BlockerID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	rows, err = r.DataBase.Query(ctx, `SELECT `+taskColumns+` FROM tasks WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer rows.Close()
+
+	tasks := make([]*domain.Task, 0, len(ids))
+	for rows.Next() {
+		task := &domain.Task{}
+		if err := scanTask(rows, task); err != nil {
+			return nil, nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return tasks, deps, r.loadDetails(ctx, tasks)
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+// setActor records userID as the author of the task changes made in tx, see
+// the record_task_event trigger.
+func setActor(ctx context.Context, tx pgx.Tx, userID int) error {
+	_, err := tx.Exec(ctx, `SELECT set_config('skillsrock.actor_id', $1, true)`, strconv.Itoa(userID))
+	return err
+}
+
+// GetTaskHistory returns a page of the events of the task, oldest first, and
+// the number of events.
+func (r *TaskRepository) GetTaskHistory(ctx context.Context, taskID int, page domain.Page) ([]*domain.TaskEvent, int, error) {
+	var total int
+	query := `SELECT COUNT(*) FROM task_events WHERE task_id = $1`
+	if err := r.DataBase.QueryRow(ctx, query, taskID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query = `SELECT e.id, e.task_id, COALESCE(e.actor_id, 0), COALESCE(u.name, ''), e.action, e.changes, e.created_at
+	FROM task_events e LEFT JOIN users u ON u.id = e.actor_id
+	WHERE e.task_id = $1 ORDER BY e.id LIMIT $2 OFFSET $3`
+
+	rows, err := r.DataBase.Query(ctx, query, taskID, page.Limit, page.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer rows.Close()
+
+	events := make([]*domain.TaskEvent, 0)
+	for rows.Next() {
+		event := &domain.TaskEvent{}
+		if err := rows.Scan(&event.ID, &event.TaskID, &event.ActorID, &event.Actor, &event.Action, &event.Changes, &event.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
+
+// GetTaskEventScope returns the owner and project of the task as of its last
+// event, so that the history of a deleted task can still be authorized.
+func (r *TaskRepository) GetTaskEventScope(ctx context.Context, taskID int) (domain.TaskScope, error) {
+	query := `SELECT COALESCE(user_id, 0), COALESCE(project_id, 0) FROM task_events WHERE task_id = $1 ORDER BY id DESC LIMIT 1`
+
+	var scope domain.TaskScope
+	if err := r.DataBase.QueryRow(ctx, query, taskID).Scan(&scope.UserID, &scope.ProjectID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return scope, domain.TaskNotFound
+		}
+		return scope, err
+	}
+
+	return scope, nil
+}
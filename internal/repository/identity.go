@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+type IdentityRepository struct {
+	DataBase *pgxpool.Pool
+}
+
+func NewIdentityRepository(db *pgxpool.Pool) IdentityRepositoryInterface {
+	return &IdentityRepository{DataBase: db}
+}
+
+func (r *IdentityRepository) GetIdentityUser(ctx context.Context, issuer, subject string) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users
+	WHERE id = (SELECT user_id FROM user_identities WHERE issuer = $1 AND subject = $2)`
+
+	user := &domain.User{}
+	if err := scanUser(r.DataBase.QueryRow(ctx, query, issuer, subject), user); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.IdentityNotFound
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *IdentityRepository) LinkIdentity(ctx context.Context, identity *domain.Identity) error {
+	query := `INSERT INTO user_identities (user_id, issuer, subject, email) VALUES ($1, $2, $3, NULLIF($4, ''))
+	ON CONFLICT (issuer, subject) DO NOTHING`
+
+	_, err := r.DataBase.Exec(ctx, query, identity.UserID, identity.Issuer, identity.Subject, identity.Email)
+	return err
+}
+
+// CreateUserWithIdentity provisions a user without a password for a first
+// login through an identity provider.
+func (r *IdentityRepository) CreateUserWithIdentity(ctx context.Context, user *domain.User, identity *domain.Identity) (*domain.User, error) {
+	tx, err := r.DataBase.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	query := `INSERT INTO users (name, display_name, email) VALUES ($1, NULLIF($2, ''), NULLIF($3, '')) RETURNING ` + userColumns
+
+	created := &domain.User{}
+	if err := scanUser(tx.QueryRow(ctx, query, user.Name, user.DisplayName, user.Email), created); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, domain.UserAlreadyExists
+		}
+		return nil, err
+	}
+
+	query = `INSERT INTO user_identities (user_id, issuer, subject, email) VALUES ($1, $2, $3, NULLIF($4, ''))`
+	if _, err := tx.Exec(ctx, query, created.ID, identity.Issuer, identity.Subject, identity.Email); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+type LabelRepository struct {
+	DataBase *pgxpool.Pool
+}
+
+func NewLabelRepository(db *pgxpool.Pool) LabelRepositoryInterface {
+	return &LabelRepository{DataBase: db}
+}
+
+const labelColumns = `id, COALESCE(user_id, 0), COALESCE(project_id, 0), name, color`
+
+// labelScope matches the labels l available to the tasks t: the labels of
+// their project, or the personal labels of their owner.
+const labelScope = `(l.project_id = t.project_id OR (t.project_id IS NULL AND l.user_id = t.user_id))`
+
+func scanLabel(row pgx.Row, label *domain.Label) error {
+	return row.Scan(&label.ID, &label.UserID, &label.ProjectID, &label.Name, &label.Color)
+}
+
+func labelError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.LabelNotFound
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return domain.LabelAlreadyExists
+	}
+	return err
+}
+
+func (r *LabelRepository) GetLabels(ctx context.Context, scope domain.TaskScope) ([]*domain.Label, error) {
+	condition, scopeArg := scopeCondition(scope)
+	query := `SELECT ` + labelColumns + ` FROM labels WHERE ` + condition + ` ORDER BY name`
+
+	rows, err := r.DataBase.Query(ctx, query, scopeArg)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	labels := make([]*domain.Label, 0)
+	for rows.Next() {
+		label := &domain.Label{}
+		if err := scanLabel(rows, label); err != nil {
+			return nil, err
+		}
+
+		labels = append(labels, label)
+	}
+
+	return labels, rows.Err()
+}
+
+func (r *LabelRepository) GetLabel(ctx context.Context, labelID int) (*domain.Label, error) {
+	query := `SELECT ` + labelColumns + ` FROM labels WHERE id = $1`
+
+	label := &domain.Label{}
+	if err := scanLabel(r.DataBase.QueryRow(ctx, query, labelID), label); err != nil {
+		return nil, labelError(err)
+	}
+
+	return label, nil
+}
+
+func (r *LabelRepository) CreateLabel(ctx context.Context, label *domain.Label) (*domain.Label, error) {
+	query := `INSERT INTO labels (user_id, project_id, name, color) VALUES (NULLIF($1, 0), NULLIF($2, 0), $3, $4) RETURNING ` + labelColumns
+
+	if label.ProjectID != 0 {
+		label.UserID = 0
+	}
+	if err := scanLabel(r.DataBase.QueryRow(ctx, query, label.UserID, label.ProjectID, label.Name, label.Color), label); err != nil {
+		return nil, labelError(err)
+	}
+
+	return label, nil
+}
+
+func (r *LabelRepository) UpdateLabel(ctx context.Context, label *domain.Label) (*domain.Label, error) {
+	query := `UPDATE labels SET name = $2, color = $3 WHERE id = $1 RETURNING ` + labelColumns
+
+	if err := scanLabel(r.DataBase.QueryRow(ctx, query, label.ID, label.Name, label.Color), label); err != nil {
+		return nil, labelError(err)
+	}
+
+	return label, nil
+}
+
+func (r *LabelRepository) DeleteLabel(ctx context.Context, labelID int) error {
+	tag, err := r.DataBase.Exec(ctx, `DELETE FROM labels WHERE id = $1`, labelID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.LabelNotFound
+	}
+
+	return nil
+}
+
+// setTaskLabels replaces the labels of the task. Labels missing in the scope
+// of the task are created with the given colour, existing ones keep theirs.
+func setTaskLabels(ctx context.Context, tx pgx.Tx, taskID int, labels []*domain.Label) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM task_labels WHERE task_id = $1`, taskID); err != nil {
+		return err
+	}
+
+	for _, label := range labels {
+		query := `INSERT INTO labels (user_id, project_id, name, color)
+		SELECT CASE WHEN project_id IS NULL THEN user_id END, project_id, $2::text, $3::text FROM tasks WHERE id = $1
+		ON CONFLICT (user_id, project_id, name) DO NOTHING`
+		if _, err := tx.Exec(ctx, query, taskID, label.Name, label.Color); err != nil {
+			return err
+		}
+
+		query = `INSERT INTO task_labels (task_id, label_id)
+		SELECT t.id, l.id FROM tasks t JOIN labels l ON ` + labelScope + ` AND l.name = $2 WHERE t.id = $1
+		ON CONFLICT DO NOTHING`
+		if _, err := tx.Exec(ctx, query, taskID, label.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadLabels fills in the labels of the tasks, ordered by name.
+func (r *TaskRepository) loadLabels(ctx context.Context, tasks []*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(tasks))
+	byID := make(map[int]*domain.Task, len(tasks))
+	for _, task := range tasks {
+		task.Labels = make([]*domain.Label, 0)
+		ids = append(ids, task.ID)
+		byID[task.ID] = task
+	}
+
+	query := `SELECT tl.task_id, l.id, COALESCE(l.user_id, 0), COALESCE(l.project_id, 0), l.name, l.color FROM task_labels tl
+	JOIN labels l ON l.id = tl.label_id WHERE tl.task_id = ANY($1) ORDER BY l.name`
+	rows, err := r.DataBase.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID int
+		label := &domain.Label{}
+		if err := rows.Scan(&taskID, &label.ID, &label.UserID, &label.ProjectID, &label.Name, &label.Color); err != nil {
+			return err
+		}
+
+		if task, ok := byID[taskID]; ok {
+			task.Labels = append(task.Labels, label)
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+type MFARepository struct {
+	DataBase *pgxpool.Pool
+}
+
+func NewMFARepository(db *pgxpool.Pool) MFARepositoryInterface {
+	return &MFARepository{DataBase: db}
+}
+
+// SetTOTPSecret stores a secret pending confirmation. It fails with
+// domain.MFAAlreadyEnabled once TOTP is enabled.
+func (r *MFARepository) SetTOTPSecret(ctx context.Context, userID int, secret string) error {
+	query := `UPDATE users SET totp_secret = $2 WHERE id = $1 AND NOT totp_enabled`
+
+	res, err := r.DataBase.Exec(ctx, query, userID, secret)
+	if err != nil {
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return domain.MFAAlreadyEnabled
+	}
+
+	return nil
+}
+
+// GetTOTPSecret returns the secret of the user and whether it is confirmed.
+func (r *MFARepository) GetTOTPSecret(ctx context.Context, userID int) (string, bool, error) {
+	query := `SELECT COALESCE(totp_secret, ''), totp_enabled FROM users WHERE id = $1`
+
+	var secret string
+	var enabled bool
+	err := r.DataBase.QueryRow(ctx, query, userID).Scan(&secret, &enabled)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, domain.UserNotFound
+		}
+		return "", false, err
+	}
+
+	if secret == "" {
+		return "", false, domain.MFANotEnrolled
+	}
+
+	return secret, enabled, nil
+}
+
+// EnableTOTP confirms the pending secret and replaces the recovery codes.
+func (r *MFARepository) EnableTOTP(ctx context.Context, userID int, recoveryCodeHashes []string) error {
+	tx, err := r.DataBase.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	query := `UPDATE users SET totp_enabled = TRUE WHERE id = $1`
+	if _, err := tx.Exec(ctx, query, userID); err != nil {
+		return err
+	}
+
+	query = `DELETE FROM recovery_codes WHERE user_id = $1`
+	if _, err := tx.Exec(ctx, query, userID); err != nil {
+		return err
+	}
+
+	query = `INSERT INTO recovery_codes (user_id, code_hash) SELECT $1, unnest($2::text[])`
+	if _, err := tx.Exec(ctx, query, userID, recoveryCodeHashes); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *MFARepository) DisableTOTP(ctx context.Context, userID int) error {
+	tx, err := r.DataBase.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	query := `UPDATE users SET totp_secret = NULL, totp_enabled = FALSE, totp_last_step = 0 WHERE id = $1`
+	if _, err := tx.Exec(ctx, query, userID); err != nil {
+		return err
+	}
+
+	query = `DELETE FROM recovery_codes WHERE user_id = $1`
+	if _, err := tx.Exec(ctx, query, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// UseTOTPStep records step as used and reports false if it or a later step
+// was used before, so that every code works only once.
+func (r *MFARepository) UseTOTPStep(ctx context.Context, userID int, step int64) (bool, error) {
+	query := `UPDATE users SET totp_last_step = $2 WHERE id = $1 AND totp_last_step < $2`
+
+	res, err := r.DataBase.Exec(ctx, query, userID, step)
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() == 1, nil
+}
+
+func (r *MFARepository) UseRecoveryCode(ctx context.Context, userID int, codeHash string) error {
+	query := `UPDATE recovery_codes SET used_at = CURRENT_TIMESTAMP WHERE id = (
+		SELECT id FROM recovery_codes WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL LIMIT 1
+	)`
+
+	res, err := r.DataBase.Exec(ctx, query, userID, codeHash)
+	if err != nil {
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return domain.InvalidMFACode
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+type PasswordResetRepository struct {
+	DataBase *pgxpool.Pool
+}
+
+func NewPasswordResetRepository(db *pgxpool.Pool) PasswordResetRepositoryInterface {
+	return &PasswordResetRepository{DataBase: db}
+}
+
+func (r *PasswordResetRepository) CreatePasswordReset(ctx context.Context, userID int, tokenHash string, ttl time.Duration) error {
+	query := `INSERT INTO password_resets (user_id, token_hash, expires_at)
+	VALUES ($1, $2, CURRENT_TIMESTAMP + make_interval(secs => $3))`
+
+	_, err := r.DataBase.Exec(ctx, query, userID, tokenHash, ttl.Seconds())
+	return err
+}
+
+// ConsumePasswordReset marks the token as used and returns its user. Used and
+// expired tokens are reported as domain.InvalidToken.
+func (r *PasswordResetRepository) ConsumePasswordReset(ctx context.Context, tokenHash string) (int, error) {
+	query := `UPDATE password_resets SET used_at = CURRENT_TIMESTAMP
+	WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP RETURNING user_id`
+
+	var userID int
+	err := r.DataBase.QueryRow(ctx, query, tokenHash).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, domain.InvalidToken
+		}
+		return 0, err
+	}
+
+	return userID, nil
+}
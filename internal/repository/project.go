@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+type ProjectRepository struct {
+	DataBase *pgxpool.Pool
+}
+
+func NewProjectRepository(db *pgxpool.Pool) ProjectRepositoryInterface {
+	return &ProjectRepository{DataBase: db}
+}
+
+func (r *ProjectRepository) CreateProject(ctx context.Context, project *domain.Project) (*domain.Project, error) {
+	tx, err := r.DataBase.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	query := `INSERT INTO projects (name, description, owner_id) VALUES ($1, $2, $3) RETURNING id, created_at, updated_at`
+	err = tx.QueryRow(ctx, query, project.Name, project.Description, project.OwnerID).Scan(&project.ID, &project.CreatedAt, &project.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `INSERT INTO project_members (project_id, user_id, role) VALUES ($1, $2, $3)`
+	if _, err := tx.Exec(ctx, query, project.ID, project.OwnerID, domain.ProjectRoleOwner); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (r *ProjectRepository) GetProjects(ctx context.Context, userID int) ([]*domain.Project, error) {
+	query := `SELECT p.id, p.name, COALESCE(p.description, ''), p.owner_id, p.created_at, p.updated_at FROM projects p
+	JOIN project_members m ON m.project_id = p.id WHERE m.user_id = $1 ORDER BY p.id`
+	rows, err := r.DataBase.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	projects := make([]*domain.Project, 0)
+	for rows.Next() {
+		project := &domain.Project{}
+		err := rows.Scan(&project.ID, &project.Name, &project.Description, &project.OwnerID, &project.CreatedAt, &project.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		projects = append(projects, project)
+	}
+
+	return projects, rows.Err()
+}
+
+func (r *ProjectRepository) GetProject(ctx context.Context, projectID int) (*domain.Project, error) {
+	query := `SELECT id, name, COALESCE(description, ''), owner_id, created_at, updated_at FROM projects WHERE id = $1`
+
+	project := &domain.Project{}
+	err := r.DataBase.QueryRow(ctx, query, projectID).Scan(&project.ID, &project.Name, &project.Description, &project.OwnerID, &project.CreatedAt, &project.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ProjectNotFound
+		}
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (r *ProjectRepository) UpdateProject(ctx context.Context, project *domain.Project) (*domain.Project, error) {
+	query := `UPDATE projects SET name = $1, description = $2 WHERE id = $3
+	RETURNING id, name, COALESCE(description, ''), owner_id, created_at, updated_at`
+	err := r.DataBase.QueryRow(ctx, query, project.Name, project.Description, project.ID).Scan(
+		&project.ID, &project.Name, &project.Description, &project.OwnerID, &project.CreatedAt, &project.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ProjectNotFound
+		}
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (r *ProjectRepository) DeleteProject(ctx context.Context, projectID int) error {
+	query := `DELETE FROM projects WHERE id = $1`
+	tag, err := r.DataBase.Exec(ctx, query, projectID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ProjectNotFound
+	}
+	return nil
+}
+
+func (r *ProjectRepository) GetMemberRole(ctx context.Context, projectID, userID int) (string, error) {
+	query := `SELECT role FROM project_members WHERE project_id = $1 AND user_id = $2`
+
+	var role string
+	err := r.DataBase.QueryRow(ctx, query, projectID, userID).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", domain.ProjectNotFound
+		}
+		return "", err
+	}
+
+	return role, nil
+}
+
+func (r *ProjectRepository) GetMembers(ctx context.Context, projectID int) ([]*domain.ProjectMember, error) {
+	query := `SELECT m.project_id, m.user_id, u.name, m.role, m.created_at FROM project_members m
+	JOIN users u ON u.id = m.user_id WHERE m.project_id = $1 ORDER BY m.created_at`
+	rows, err := r.DataBase.Query(ctx, query, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	members := make([]*domain.ProjectMember, 0)
+	for rows.Next() {
+		member := &domain.ProjectMember{}
+		err := rows.Scan(&member.ProjectID, &member.UserID, &member.Name, &member.Role, &member.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, member)
+	}
+
+	return members, rows.Err()
+}
+
+func (r *ProjectRepository) SetMember(ctx context.Context, member *domain.ProjectMember) error {
+	query := `INSERT INTO project_members (project_id, user_id, role) VALUES ($1, $2, $3)
+	ON CONFLICT (project_id, user_id) DO UPDATE SET role = EXCLUDED.role`
+	_, err := r.DataBase.Exec(ctx, query, member.ProjectID, member.UserID, member.Role)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.UserNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *ProjectRepository) RemoveMember(ctx context.Context, projectID, userID int) error {
+	query := `DELETE FROM project_members WHERE project_id = $1 AND user_id = $2`
+	tag, err := r.DataBase.Exec(ctx, query, projectID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ProjectMemberNotFound
+	}
+	return nil
+}
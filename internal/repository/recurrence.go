@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+const seriesColumns = `id, rule, starts_at, last_at, next_at`
+
+func scanSeries(row pgx.Row, series *domain.TaskSeries) error {
+	return row.Scan(&series.ID, &series.Rule, &series.StartsAt, &series.LastAt, &series.NextAt)
+}
+
+// insertSeries creates the series of a new recurring task from the task and
+// returns its id, or 0 when the task does not recur.
+func insertSeries(ctx context.Context, tx pgx.Tx, task *domain.Task) (int, error) {
+	if task.Recurrence == "" {
+		return 0, nil
+	}
+
+	query := `INSERT INTO task_series (user_id, project_id, parent_id, rule, title, description, priority, auto_complete, starts_at, last_at, next_at, reminders)
+	VALUES ($1, NULLIF($2, 0), NULLIF($3, 0), $4, $5, $6, $7, $8, $9, $9, $10, COALESCE($11::int[], '{}')) RETURNING id`
+
+	var id int
+	err := tx.QueryRow(ctx, query, task.UserID, task.ProjectID, task.ParentID, task.Recurrence, task.Title, task.Description, task.Priority, task.AutoComplete, task.Due_date, task.NextOccurrence, task.Reminders).Scan(&id)
+	return id, err
+}
+
+// startSeries makes an existing task the first occurrence of a new series.
+func startSeries(ctx context.Context, tx pgx.Tx, task *domain.Task) error {
+	seriesID, err := insertSeries(ctx, tx, task)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE tasks SET series_id = $2, occurs_at = due_date WHERE id = $1`, task.ID, seriesID); err != nil {
+		return err
+	}
+	task.SeriesID, task.OccursAt = seriesID, task.Due_date
+
+	return setSeriesLabels(ctx, tx, seriesID, task.ID)
+}
+
+// updateSeries copies the task into the template of its series and into the
+// later open occurrences. A changed rule counts from the task onwards.
+func updateSeries(ctx context.Context, tx pgx.Tx, task *domain.Task) error {
+	query := `UPDATE task_series s SET rule = $2, next_at = $3,
+	starts_at = CASE WHEN s.rule = $2 THEN s.starts_at ELSE t.occurs_at END,
+	title = t.title, description = t.description, priority = t.priority, auto_complete = t.auto_complete,
+	reminders = ARRAY(SELECT minutes_before FROM task_reminders WHERE task_id = t.id ORDER BY minutes_before DESC)
+	FROM tasks t WHERE t.id = $1 AND s.id = t.series_id`
+	if _, err := tx.Exec(ctx, query, task.ID, task.Recurrence, task.NextOccurrence); err != nil {
+		return err
+	}
+
+	if err := setSeriesLabels(ctx, tx, task.SeriesID, task.ID); err != nil {
+		return err
+	}
+
+	query = `UPDATE tasks o SET title = t.title, description = t.description, priority = t.priority, auto_complete = t.auto_complete
+	FROM tasks t WHERE t.id = $1 AND o.series_id = t.series_id AND o.occurs_at > t.occurs_at AND o.deleted_at IS NULL AND ` + openTask("o.") + `
+	RETURNING o.id`
+	rows, err := tx.Query(ctx, query, task.ID)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM task_labels WHERE task_id = ANY($1)`, ids); err != nil {
+		return err
+	}
+
+	query = `INSERT INTO task_labels (task_id, label_id)
+	SELECT o.id, tl.label_id FROM unnest($1::int[]) AS o(id), task_labels tl WHERE tl.task_id = $2`
+	if _, err := tx.Exec(ctx, query, ids, task.ID); err != nil {
+		return err
+	}
+
+	// Reminders kept from before keep their state, as in setTaskReminders.
+	query = `DELETE FROM task_reminders WHERE task_id = ANY($1)
+	AND minutes_before NOT IN (SELECT minutes_before FROM task_reminders WHERE task_id = $2)`
+	if _, err := tx.Exec(ctx, query, ids, task.ID); err != nil {
+		return err
+	}
+
+	query = `INSERT INTO task_reminders (task_id, minutes_before)
+	SELECT o.id, r.minutes_before FROM unnest($1::int[]) AS o(id), task_reminders r WHERE r.task_id = $2
+	ON CONFLICT DO NOTHING`
+	_, err = tx.Exec(ctx, query, ids, task.ID)
+	return err
+}
+
+// setSeriesLabels replaces the labels of the series with those of the task.
+func setSeriesLabels(ctx context.Context, tx pgx.Tx, seriesID, taskID int) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM task_series_labels WHERE series_id = $1`, seriesID); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO task_series_labels (series_id, label_id) SELECT $1, label_id FROM task_labels WHERE task_id = $2`
+	_, err := tx.Exec(ctx, query, seriesID, taskID)
+	return err
+}
+
+// loadSeries fills in the recurrence of the occurrences among the tasks.
+func (r *TaskRepository) loadSeries(ctx context.Context, tasks []*domain.Task) error {
+	ids := make([]int, 0)
+	for _, task := range tasks {
+		task.Recurrence, task.NextOccurrence = "", nil
+		if task.SeriesID != 0 {
+			ids = append(ids, task.SeriesID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := r.DataBase.Query(ctx, `SELECT `+seriesColumns+` FROM task_series WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	series := make(map[int]*domain.TaskSeries)
+	for rows.Next() {
+		s := &domain.TaskSeries{}
+		if err := scanSeries(rows, s); err != nil {
+			return err
+		}
+		series[s.ID] = s
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		if s, ok := series[task.SeriesID]; ok {
+			task.Recurrence, task.NextOccurrence = s.Rule, s.NextAt
+		}
+	}
+
+	return nil
+}
+
+func (r *TaskRepository) GetSeries(ctx context.Context, seriesID int) (*domain.TaskSeries, error) {
+	series := &domain.TaskSeries{}
+	if err := scanSeries(r.DataBase.QueryRow(ctx, `SELECT `+seriesColumns+` FROM task_series WHERE id = $1`, seriesID), series); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.TaskNotFound
+		}
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// GetDueSeries returns the series whose next occurrence is due at now.
+func (r *TaskRepository) GetDueSeries(ctx context.Context, now time.Time) ([]*domain.TaskSeries, error) {
+	rows, err := r.DataBase.Query(ctx, `SELECT `+seriesColumns+` FROM task_series WHERE next_at <= $1 ORDER BY next_at`, now)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	series := make([]*domain.TaskSeries, 0)
+	for rows.Next() {
+		s := &domain.TaskSeries{}
+		if err := scanSeries(rows, s); err != nil {
+			return nil, err
+		}
+
+		series = append(series, s)
+	}
+
+	return series, rows.Err()
+}
+
+// CreateOccurrence creates the occurrence of the series at slot from its
+// template and moves the series on to next, nil once the rule has ended. It
+// reports false when the series has moved on from series.NextAt meanwhile.
+func (r *TaskRepository) CreateOccurrence(ctx context.Context, series *domain.TaskSeries, slot time.Time, next *time.Time) (bool, error) {
+	tx, err := r.DataBase.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	query := `UPDATE task_series SET last_at = $2, next_at = $3 WHERE id = $1 AND next_at = $4`
+	tag, err := tx.Exec(ctx, query, series.ID, slot, next, series.NextAt)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	// A parent in the trash is left out, as when restoring a task.
+	query = `INSERT INTO tasks (title, description, status, priority, due_date, user_id, project_id, parent_id, auto_complete, series_id, occurs_at)
+	SELECT s.title, s.description, workflow_status(s.project_id, 'todo'), s.priority, $2::timestamp, s.user_id, s.project_id,
+		(SELECT p.id FROM tasks p WHERE p.id = s.parent_id AND p.deleted_at IS NULL), s.auto_complete, s.id, $2::timestamp
+	FROM task_series s WHERE s.id = $1 RETURNING id`
+	var id int
+	if err := tx.QueryRow(ctx, query, series.ID, slot).Scan(&id); err != nil {
+		return false, err
+	}
+
+	query = `INSERT INTO task_labels (task_id, label_id) SELECT $1, label_id FROM task_series_labels WHERE series_id = $2`
+	if _, err := tx.Exec(ctx, query, id, series.ID); err != nil {
+		return false, err
+	}
+
+	query = `INSERT INTO task_reminders (task_id, minutes_before) SELECT $1, unnest(reminders) FROM task_series WHERE id = $2`
+	if _, err := tx.Exec(ctx, query, id, series.ID); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit(ctx)
+}
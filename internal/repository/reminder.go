@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+// reminderAttempts is how often delivery of a reminder is tried for a due
+// date before it is given up until the task changes.
+const reminderAttempts = 5
+
+// reminderLease is how long a claimed reminder is left to its worker. It
+// outlasts a batch of slow deliveries and spaces out retries.
+const reminderLease = 10 * time.Minute
+
+// setTaskReminders replaces the reminders of the task. Reminders kept from
+// before keep their state, so they are not sent again for the same due date.
+func setTaskReminders(ctx context.Context, tx pgx.Tx, taskID int, minutes []int) error {
+	if minutes == nil {
+		minutes = []int{}
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM task_reminders WHERE task_id = $1 AND minutes_before <> ALL($2::int[])`, taskID, minutes); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE task_reminders SET attempts = 0 WHERE task_id = $1`, taskID); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO task_reminders (task_id, minutes_before) SELECT $1, unnest($2::int[]) ON CONFLICT DO NOTHING`
+	_, err := tx.Exec(ctx, query, taskID, minutes)
+	return err
+}
+
+// loadReminders fills in the reminders of the tasks, earliest first.
+func (r *TaskRepository) loadReminders(ctx context.Context, tasks []*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(tasks))
+	byID := make(map[int]*domain.Task, len(tasks))
+	for _, task := range tasks {
+		task.Reminders = nil
+		ids = append(ids, task.ID)
+		byID[task.ID] = task
+	}
+
+	query := `SELECT task_id, minutes_before FROM task_reminders WHERE task_id = ANY($1) ORDER BY minutes_before DESC`
+	rows, err := r.DataBase.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID, minutes int
+		if err := rows.Scan(&taskID, &minutes); err != nil {
+			return err
+		}
+
+		if task, ok := byID[taskID]; ok {
+			task.Reminders = append(task.Reminders, minutes)
+		}
+	}
+
+	return rows.Err()
+}
+
+// ClaimReminders leases up to limit reminders that are due at now to the
+// caller, who delivers them and reports back with RecordReminder. A reminder
+// is due from minutes_before its due date until the due date, for open tasks
+// outside the trash. The claim is committed right away, so no locks are held
+// during delivery, and other workers skip the reminder until the lease runs
+// out: it is only sent twice if its worker dies before recording it.
+func (r *TaskRepository) ClaimReminders(ctx context.Context, now time.Time, limit int) ([]*domain.Reminder, error) {
+	query := `WITH due AS (
+		SELECT r.task_id, r.minutes_before, t.title, t.due_date, COALESCE(NULLIF(u.email, ''), u.name) AS recipient, COALESCE(u.email, '') AS email
+		FROM task_reminders r JOIN tasks t ON t.id = r.task_id JOIN users u ON u.id = t.user_id
+		WHERE r.sent_for IS DISTINCT FROM t.due_date
+			AND (r.attempts < $3 OR r.attempts_for IS DISTINCT FROM t.due_date)
+			AND (r.claimed_until IS NULL OR r.claimed_until <= $1)
+			AND t.deleted_at IS NULL AND ` + openTask("t.") + `
+			AND t.due_date > $1 AND t.due_date - r.minutes_before * INTERVAL '1 minute' <= $1
+		ORDER BY t.due_date LIMIT $2
+		FOR UPDATE OF r SKIP LOCKED
+	)
+	UPDATE task_reminders c SET claimed_until = $4
+	FROM due WHERE c.task_id = due.task_id AND c.minutes_before = due.minutes_before
+	RETURNING due.task_id, due.minutes_before, due.title, due.due_date, due.recipient, due.email`
+	rows, err := r.DataBase.Query(ctx, query, now, limit, reminderAttempts, now.Add(reminderLease))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	reminders := make([]*domain.Reminder, 0)
+	for rows.Next() {
+		reminder := &domain.Reminder{}
+		if err := rows.Scan(&reminder.TaskID, &reminder.MinutesBefore, &reminder.Title, &reminder.DueDate, &reminder.Recipient, &reminder.Email); err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	return reminders, rows.Err()
+}
+
+// RecordReminder reports the delivery of a claimed reminder. A sent reminder
+// is done for the due date it was claimed for. A failed one counts an attempt
+// for that due date, starting over if the attempts so far were for another,
+// and keeps its claim, so it is retried once the lease runs out.
+func (r *TaskRepository) RecordReminder(ctx context.Context, reminder *domain.Reminder, sent bool) error {
+	query := `UPDATE task_reminders
+	SET attempts = CASE WHEN attempts_for IS NOT DISTINCT FROM $3 THEN attempts + 1 ELSE 1 END, attempts_for = $3
+	WHERE task_id = $1 AND minutes_before = $2`
+	if sent {
+		query = `UPDATE task_reminders SET sent_for = $3, attempts = 0, attempts_for = NULL, claimed_until = NULL
+		WHERE task_id = $1 AND minutes_before = $2`
+	}
+
+	_, err := r.DataBase.Exec(ctx, query, reminder.TaskID, reminder.MinutesBefore, reminder.DueDate)
+	return err
+}
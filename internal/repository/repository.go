@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/wazwki/skillsrock/internal/domain"
 )
@@ -9,17 +10,135 @@ import (
 type TaskRepositoryInterface interface {
 	CreateTask(ctx context.Context, task *domain.Task) (string, error)
 	GetTasks(ctx context.Context, filter domain.TaskFilter) ([]*domain.Task, error)
-	UpdateTask(ctx context.Context, task *domain.Task) (*domain.Task, error)
-	DeleteTask(ctx context.Context, task_id string) error
+	UpdateTask(ctx context.Context, task *domain.Task, future bool) (*domain.Task, error)
+	GetTask(ctx context.Context, userID, taskID int) (*domain.Task, error)
+	GetSubtree(ctx context.Context, taskID int) ([]*domain.Task, error)
+	SetParent(ctx context.Context, userID, taskID, parentID int) (*domain.Task, error)
+	CompleteAncestors(ctx context.Context, taskID int) error
+	GetBlockers(ctx context.Context, taskID int) ([]*domain.Task, error)
+	AddDependency(ctx context.Context, userID int, dep domain.TaskDependency) error
+	RemoveDependency(ctx context.Context, userID int, dep domain.TaskDependency) error
+	CountOpenBlockers(ctx context.Context, taskID int) (int, error)
+	GetDependencyGraph(ctx context.Context, taskID int) ([]*domain.Task, []domain.TaskDependency, error)
+	DeleteTask(ctx context.Context, userID int, task_id string, reparent bool) (int, error)
 	ClearTasks(ctx context.Context) error
-	GetCachedAnalytics(ctx context.Context) (*domain.Analyse, error)
-	GetAnalytics(ctx context.Context) (*domain.Analyse, error)
-	SetAnalytics(ctx context.Context, task *domain.Analyse) error
+	GetAnalyticsScopes(ctx context.Context) ([]domain.TaskScope, error)
+	GetCachedAnalytics(ctx context.Context, scope domain.TaskScope) (*domain.Analyse, error)
+	GetAnalytics(ctx context.Context, scope domain.TaskScope) (*domain.Analyse, error)
+	SetAnalytics(ctx context.Context, scope domain.TaskScope, task *domain.Analyse) error
 	ImportTasks(ctx context.Context, task []*domain.Task) error
-	ExportTasks(ctx context.Context) ([]*domain.Task, error)
+	ExportTasks(ctx context.Context, scope domain.TaskScope) ([]*domain.Task, error)
+	GetTaskHistory(ctx context.Context, taskID int, page domain.Page) ([]*domain.TaskEvent, int, error)
+	GetTaskEventScope(ctx context.Context, taskID int) (domain.TaskScope, error)
+	GetTrash(ctx context.Context, scope domain.TaskScope) ([]*domain.Task, error)
+	RestoreTask(ctx context.Context, userID, taskID int) (*domain.Task, error)
+	PurgeTasks(ctx context.Context, olderThan time.Duration) (int64, error)
+	GetSeries(ctx context.Context, seriesID int) (*domain.TaskSeries, error)
+	GetDueSeries(ctx context.Context, now time.Time) ([]*domain.TaskSeries, error)
+	CreateOccurrence(ctx context.Context, series *domain.TaskSeries, slot time.Time, next *time.Time) (bool, error)
+	ClaimReminders(ctx context.Context, now time.Time, limit int) ([]*domain.Reminder, error)
+	RecordReminder(ctx context.Context, reminder *domain.Reminder, sent bool) error
+}
+
+type LabelRepositoryInterface interface {
+	GetLabels(ctx context.Context, scope domain.TaskScope) ([]*domain.Label, error)
+	GetLabel(ctx context.Context, labelID int) (*domain.Label, error)
+	CreateLabel(ctx context.Context, label *domain.Label) (*domain.Label, error)
+	UpdateLabel(ctx context.Context, label *domain.Label) (*domain.Label, error)
+	DeleteLabel(ctx context.Context, labelID int) error
+}
+
+type WorkflowRepositoryInterface interface {
+	GetWorkflow(ctx context.Context, projectID int) (*domain.Workflow, error)
+	SetWorkflow(ctx context.Context, workflow *domain.Workflow) (*domain.Workflow, error)
+	DeleteWorkflow(ctx context.Context, projectID int) error
+}
+
+type CommentRepositoryInterface interface {
+	GetComments(ctx context.Context, taskID int, page domain.Page) ([]*domain.Comment, int, error)
+	GetComment(ctx context.Context, commentID int) (*domain.Comment, error)
+	CreateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error)
+	UpdateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error)
+	DeleteComment(ctx context.Context, commentID int) error
+}
+
+type AttachmentRepositoryInterface interface {
+	GetAttachments(ctx context.Context, taskID int) ([]*domain.Attachment, error)
+	GetAttachment(ctx context.Context, taskID, attachmentID int) (*domain.Attachment, error)
+	CreateAttachment(ctx context.Context, attachment *domain.Attachment) (*domain.Attachment, error)
+	DeleteAttachment(ctx context.Context, taskID, attachmentID int) error
+	GetOrphanedBlobs(ctx context.Context, limit int) ([]string, error)
+	RemoveOrphanedBlobs(ctx context.Context, keys []string) error
+}
+
+type ProjectRepositoryInterface interface {
+	CreateProject(ctx context.Context, project *domain.Project) (*domain.Project, error)
+	GetProjects(ctx context.Context, userID int) ([]*domain.Project, error)
+	GetProject(ctx context.Context, projectID int) (*domain.Project, error)
+	UpdateProject(ctx context.Context, project *domain.Project) (*domain.Project, error)
+	DeleteProject(ctx context.Context, projectID int) error
+	GetMemberRole(ctx context.Context, projectID, userID int) (string, error)
+	GetMembers(ctx context.Context, projectID int) ([]*domain.ProjectMember, error)
+	SetMember(ctx context.Context, member *domain.ProjectMember) error
+	RemoveMember(ctx context.Context, projectID, userID int) error
 }
 
 type UserRepositoryInterface interface {
 	CreateUser(ctx context.Context, user *domain.User) (*domain.User, error)
 	CheckUser(ctx context.Context, user *domain.User) (*domain.User, error)
+	GetUser(ctx context.Context, userID int) (*domain.User, error)
+	GetUsers(ctx context.Context) ([]*domain.User, error)
+	SetUserRole(ctx context.Context, userID int, role string) error
+	SetUserDisabled(ctx context.Context, userID int, disabled bool) error
+	SetPasswordResetRequired(ctx context.Context, userID int, required bool) error
+	UpdatePassword(ctx context.Context, userID int, password string) error
+	RehashPassword(ctx context.Context, userID int, oldPassword, newPassword string) error
+	UpdateProfile(ctx context.Context, user *domain.User) (*domain.User, error)
+	DeleteUser(ctx context.Context, userID int) error
+}
+
+type PasswordResetRepositoryInterface interface {
+	CreatePasswordReset(ctx context.Context, userID int, tokenHash string, ttl time.Duration) error
+	ConsumePasswordReset(ctx context.Context, tokenHash string) (int, error)
+}
+
+type IdentityRepositoryInterface interface {
+	GetIdentityUser(ctx context.Context, issuer, subject string) (*domain.User, error)
+	LinkIdentity(ctx context.Context, identity *domain.Identity) error
+	CreateUserWithIdentity(ctx context.Context, user *domain.User, identity *domain.Identity) (*domain.User, error)
+}
+
+type TokenRepositoryInterface interface {
+	CreateRefreshFamily(ctx context.Context, session *domain.Session, tokenID string, ttl time.Duration) error
+	RotateRefreshFamily(ctx context.Context, userID int, family string, oldTokenID, newTokenID string, ttl time.Duration) error
+	TouchSession(ctx context.Context, family string, ip string, seenAt time.Time, ttl time.Duration) error
+	GetSession(ctx context.Context, family string) (*domain.Session, error)
+	GetSessions(ctx context.Context, userID int) ([]*domain.Session, error)
+	DeleteRefreshFamily(ctx context.Context, userID int, family string) error
+	DeleteUserRefreshFamilies(ctx context.Context, userID int) error
+	RevokeToken(ctx context.Context, tokenID string, ttl time.Duration) error
+	IsTokenRevoked(ctx context.Context, tokenID string, family string) (bool, error)
+}
+
+type LoginAttemptRepositoryInterface interface {
+	GetLockout(ctx context.Context, name string) (time.Duration, error)
+	RecordLoginFailure(ctx context.Context, name string, maxFailures int, lockout time.Duration) (time.Duration, error)
+	ResetLoginFailures(ctx context.Context, name string) error
+}
+
+type MFARepositoryInterface interface {
+	SetTOTPSecret(ctx context.Context, userID int, secret string) error
+	GetTOTPSecret(ctx context.Context, userID int) (string, bool, error)
+	EnableTOTP(ctx context.Context, userID int, recoveryCodeHashes []string) error
+	DisableTOTP(ctx context.Context, userID int) error
+	UseTOTPStep(ctx context.Context, userID int, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID int, codeHash string) error
+}
+
+type APIKeyRepositoryInterface interface {
+	CreateAPIKey(ctx context.Context, key *domain.APIKey) (*domain.APIKey, error)
+	GetAPIKeys(ctx context.Context, userID int) ([]*domain.APIKey, error)
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, keyID int) error
+	TouchAPIKey(ctx context.Context, keyID int) error
 }
@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -20,30 +23,117 @@ func NewTaskRepository(db *pgxpool.Pool, cache *redis.Client) TaskRepositoryInte
 	return &TaskRepository{DataBase: db, Cache: cache}
 }
 
+const taskColumns = `id, user_id, COALESCE(project_id, 0), COALESCE(parent_id, 0), title, description, status, COALESCE(status_category(project_id, status)::text, ''), priority, due_date, created_at, updated_at, auto_complete, deleted_at, COALESCE(series_id, 0), COALESCE(occurs_at, due_date)`
+
+// editableByUser restricts a statement to tasks the user owns or may edit as an owner or editor of their project.
+const editableByUser = `(user_id = $%[1]d OR project_id IN (SELECT project_id FROM project_members WHERE user_id = $%[1]d AND role IN ('owner', 'editor')))`
+
+// visibleToUser restricts a statement to tasks the user owns or whose project they are a member of.
+const visibleToUser = `(user_id = $%[1]d OR project_id IN (SELECT project_id FROM project_members WHERE user_id = $%[1]d))`
+
+// liveTask excludes tasks in the trash.
+const liveTask = `deleted_at IS NULL`
+
+// doneTask matches tasks whose status is in the done category of their
+// workflow, openTask the others. alias qualifies the columns.
+func doneTask(alias string) string {
+	return `status_category(` + alias + `project_id, ` + alias + `status) = 'done'`
+}
+
+func openTask(alias string) string {
+	return `status_category(` + alias + `project_id, ` + alias + `status) IS DISTINCT FROM 'done'`
+}
+
+// staleTask matches the tasks ClearTasks moves to the trash, alias qualifies the columns.
+func staleTask(alias string) string {
+	return `(` + openTask(alias) + ` AND ` + alias + `due_date < CURRENT_DATE - INTERVAL '7 days')`
+}
+
+func scanTask(row pgx.Row, task *domain.Task) error {
+	return row.Scan(&task.ID, &task.UserID, &task.ProjectID, &task.ParentID, &task.Title, &task.Description, &task.Status, &task.StatusCategory, &task.Priority, &task.Due_date, &task.CreatedAt, &task.UpdatedAt, &task.AutoComplete, &task.DeletedAt, &task.SeriesID, &task.OccursAt)
+}
+
+// loadDetails fills in the labels, comment counts, recurrence and reminders
+// of the tasks.
+func (r *TaskRepository) loadDetails(ctx context.Context, tasks []*domain.Task) error {
+	if err := r.loadLabels(ctx, tasks); err != nil {
+		return err
+	}
+	if err := r.loadCommentCounts(ctx, tasks); err != nil {
+		return err
+	}
+	if err := r.loadSeries(ctx, tasks); err != nil {
+		return err
+	}
+	return r.loadReminders(ctx, tasks)
+}
+
+func scopeCondition(scope domain.TaskScope) (string, any) {
+	if scope.ProjectID != 0 {
+		return "project_id = $1", scope.ProjectID
+	}
+	return "user_id = $1", scope.UserID
+}
+
 func (r *TaskRepository) CreateTask(ctx context.Context, task *domain.Task) (string, error) {
-	query := `INSERT INTO tasks (title, description, status, priority, due_date) VALUES ($1, $2, $3, $4, $5) RETURNING id`
-	var id string
+	tx, err := r.DataBase.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := setActor(ctx, tx, task.UserID); err != nil {
+		return "", err
+	}
 
-	err := r.DataBase.QueryRow(ctx, query, task.Title, task.Description, task.Status, task.Priority, task.Due_date).Scan(&id)
+	seriesID, err := insertSeries(ctx, tx, task)
 	if err != nil {
 		return "", err
 	}
 
-	return id, nil
+	query := `INSERT INTO tasks (title, description, status, priority, due_date, user_id, project_id, parent_id, auto_complete, series_id, occurs_at)
+	VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, 0), NULLIF($8, 0), $9, NULLIF($10, 0), CASE WHEN $10 <> 0 THEN $5::timestamp END) RETURNING id`
+	var id int
+
+	err = tx.QueryRow(ctx, query, task.Title, task.Description, task.Status, task.Priority, task.Due_date, task.UserID, task.ProjectID, task.ParentID, task.AutoComplete, seriesID).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	if err := setTaskLabels(ctx, tx, id, task.Labels); err != nil {
+		return "", err
+	}
+
+	if err := setTaskReminders(ctx, tx, id, task.Reminders); err != nil {
+		return "", err
+	}
+
+	if seriesID != 0 {
+		if err := setSeriesLabels(ctx, tx, seriesID, id); err != nil {
+			return "", err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(id), nil
 }
 
 func (r *TaskRepository) GetTasks(ctx context.Context, filter domain.TaskFilter) ([]*domain.Task, error) {
-	query := `SELECT id, title, description, status, priority, due_date, created_at, updated_at FROM tasks WHERE 1=1`
+	condition, scopeArg := scopeCondition(domain.TaskScope{UserID: filter.UserID, ProjectID: filter.ProjectID})
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE ` + liveTask + ` AND ` + condition
+	args := []any{scopeArg}
 
 	if filter.Status != "" {
-		switch filter.Status {
-		case "pending":
-			query += " AND status = 'pending'"
-		case "in_progress":
-			query += " AND status = 'in_progress'"
-		case "done":
-			query += " AND status = 'done'"
-		}
+		args = append(args, filter.Status)
+		query += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+
+	if filter.Category != "" {
+		args = append(args, filter.Category)
+		query += fmt.Sprintf(" AND status_category(project_id, status) = $%d::status_category", len(args))
 	}
 
 	if filter.Priority != "" {
@@ -57,6 +147,21 @@ func (r *TaskRepository) GetTasks(ctx context.Context, filter domain.TaskFilter)
 		}
 	}
 
+	if filter.Name != "" {
+		args = append(args, filter.Name)
+		query += fmt.Sprintf(" AND title = $%d", len(args))
+	}
+
+	if len(filter.Labels) > 0 {
+		args = append(args, filter.Labels)
+		labelled := fmt.Sprintf(`SELECT tl.task_id FROM task_labels tl JOIN labels l ON l.id = tl.label_id WHERE l.name = ANY($%d)`, len(args))
+		if filter.LabelMatch == domain.LabelMatchAll {
+			args = append(args, len(filter.Labels))
+			labelled += fmt.Sprintf(` GROUP BY tl.task_id HAVING COUNT(DISTINCT l.name) = $%d`, len(args))
+		}
+		query += " AND id IN (" + labelled + ")"
+	}
+
 	if filter.SortBy != "" {
 		switch filter.SortBy {
 		case "low":
@@ -66,19 +171,111 @@ func (r *TaskRepository) GetTasks(ctx context.Context, filter domain.TaskFilter)
 		}
 	}
 
-	var rows pgx.Rows
-	var err error
-	if filter.Name != "" {
-		query += " AND title = $1"
-		rows, err = r.DataBase.Query(ctx, query, filter.Name)
-		if err != nil {
+	rows, err := r.DataBase.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	tasks := make([]*domain.Task, 0)
+	for rows.Next() {
+		task := &domain.Task{}
+		if err := scanTask(rows, task); err != nil {
 			return nil, err
 		}
-	} else {
-		rows, err = r.DataBase.Query(ctx, query)
-		if err != nil {
-			return nil, err
+
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tasks, r.loadDetails(ctx, tasks)
+}
+
+// UpdateTask changes the task. A task with Recurrence that is not recurring
+// yet starts a series. With future set the change also applies to the series
+// of the task and its later open occurrences, an empty Recurrence ends the
+// series.
+func (r *TaskRepository) UpdateTask(ctx context.Context, task *domain.Task, future bool) (*domain.Task, error) {
+	tx, err := r.DataBase.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := setActor(ctx, tx, task.UserID); err != nil {
+		return nil, err
+	}
+
+	labels, reminders, recurrence, nextOccurrence := task.Labels, task.Reminders, task.Recurrence, task.NextOccurrence
+	query := `UPDATE tasks SET title = $1, description = $2, status = $3, priority = $4, due_date = $5, auto_complete = $8
+	WHERE id = $6 AND ` + liveTask + ` AND ` + fmt.Sprintf(editableByUser, 7) + `
+	RETURNING ` + taskColumns
+	err = scanTask(tx.QueryRow(ctx, query, task.Title, task.Description, task.Status, task.Priority, task.Due_date, task.ID, task.UserID, task.AutoComplete), task)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.TaskNotFound
 		}
+		return nil, err
+	}
+
+	if err := setTaskLabels(ctx, tx, task.ID, labels); err != nil {
+		return nil, err
+	}
+
+	if err := setTaskReminders(ctx, tx, task.ID, reminders); err != nil {
+		return nil, err
+	}
+
+	task.Reminders, task.Recurrence, task.NextOccurrence = reminders, recurrence, nextOccurrence
+	switch {
+	case task.SeriesID == 0 && recurrence != "":
+		err = startSeries(ctx, tx, task)
+	case task.SeriesID != 0 && future && recurrence == "":
+		_, err = tx.Exec(ctx, `DELETE FROM task_series WHERE id = $1`, task.SeriesID)
+	case task.SeriesID != 0 && future:
+		err = updateSeries(ctx, tx, task)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return task, r.loadDetails(ctx, []*domain.Task{task})
+}
+
+func (r *TaskRepository) GetTask(ctx context.Context, userID, taskID int) (*domain.Task, error) {
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE id = $1 AND ` + liveTask + ` AND ` + fmt.Sprintf(visibleToUser, 2)
+
+	task := &domain.Task{}
+	if err := scanTask(r.DataBase.QueryRow(ctx, query, taskID, userID), task); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.TaskNotFound
+		}
+		return nil, err
+	}
+
+	return task, r.loadDetails(ctx, []*domain.Task{task})
+}
+
+// GetSubtree returns all descendants of the task outside the trash ordered by id.
+func (r *TaskRepository) GetSubtree(ctx context.Context, taskID int) ([]*domain.Task, error) {
+	query := `WITH RECURSIVE subtree AS (
+		SELECT id FROM tasks WHERE parent_id = $1 AND ` + liveTask + `
+		UNION
+		SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+	)
+	SELECT ` + taskColumns + ` FROM tasks WHERE id IN (SELECT id FROM subtree) ORDER BY id`
+
+	rows, err := r.DataBase.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, err
 	}
 
 	defer rows.Close()
@@ -86,54 +283,188 @@ func (r *TaskRepository) GetTasks(ctx context.Context, filter domain.TaskFilter)
 	tasks := make([]*domain.Task, 0)
 	for rows.Next() {
 		task := &domain.Task{}
-		err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.Status, &task.Priority, &task.Due_date, &task.CreatedAt, &task.UpdatedAt)
-		if err != nil {
+		if err := scanTask(rows, task); err != nil {
 			return nil, err
 		}
 
 		tasks = append(tasks, task)
 	}
 
-	return tasks, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tasks, r.loadDetails(ctx, tasks)
 }
 
-func (r *TaskRepository) UpdateTask(ctx context.Context, task *domain.Task) (*domain.Task, error) {
-	query := `UPDATE tasks SET title = $1, description = $2, status = $3, priority = $4, due_date = $5 WHERE id = $6 
-	RETURNING id, title, description, status, priority, due_date, created_at, updated_at`
-	err := r.DataBase.QueryRow(ctx, query, task.Title, task.Description, task.Status, task.Priority, task.Due_date, task.ID).Scan(
-		&task.ID, &task.Title, &task.Description, &task.Status, &task.Priority, &task.Due_date, &task.CreatedAt, &task.UpdatedAt)
+// SetParent moves the task under parentID, or to the top level for 0.
+// A parent inside the subtree of the task is rejected.
+func (r *TaskRepository) SetParent(ctx context.Context, userID, taskID, parentID int) (*domain.Task, error) {
+	query := `WITH RECURSIVE subtree AS (
+		SELECT $1::int AS id
+		UNION
+		SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id
+	)
+	UPDATE tasks SET parent_id = NULLIF($2, 0)
+	WHERE id = $1 AND ` + liveTask + ` AND ` + fmt.Sprintf(editableByUser, 3) + ` AND $2 NOT IN (SELECT id FROM subtree)
+	RETURNING ` + taskColumns
+
+	tx, err := r.DataBase.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer tx.Rollback(ctx) //nolint:errcheck
 
-	return task, nil
+	if err := setActor(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	task := &domain.Task{}
+	if err := scanTask(tx.QueryRow(ctx, query, taskID, parentID, userID), task); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.InvalidParentTask
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return task, r.loadDetails(ctx, []*domain.Task{task})
 }
 
-func (r *TaskRepository) DeleteTask(ctx context.Context, task_id string) error {
-	query := `DELETE FROM tasks WHERE id = $1`
-	_, err := r.DataBase.Exec(ctx, query, task_id)
-	if err != nil {
-		return err
+// CompleteAncestors moves the task to the first done status of its workflow
+// if it completes automatically and all of its subtasks and blockers are
+// done, and continues with its parent. Transitions are not checked.
+func (r *TaskRepository) CompleteAncestors(ctx context.Context, taskID int) error {
+	query := `UPDATE tasks SET status = workflow_status(project_id, 'done')
+	WHERE id = $1 AND ` + liveTask + ` AND auto_complete AND ` + openTask("") + `
+	AND EXISTS (SELECT 1 FROM tasks WHERE parent_id = $1 AND ` + liveTask + `)
+	AND NOT EXISTS (SELECT 1 FROM tasks WHERE parent_id = $1 AND ` + liveTask + ` AND ` + openTask("") + `)
+	AND NOT EXISTS (SELECT 1 FROM task_dependencies d JOIN tasks b ON b.id = d.blocker_id WHERE d.task_id = $1 AND b.deleted_at IS NULL AND ` + openTask("b.") + `)
+	RETURNING COALESCE(parent_id, 0)`
+
+	for taskID != 0 {
+		err := r.DataBase.QueryRow(ctx, query, taskID).Scan(&taskID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
 	}
+
 	return nil
 }
 
+// DeleteTask moves the task with its subtasks to the trash, or moves the
+// subtasks to its parent first when reparent is set. The trashed tasks share
+// deleted_at, see RestoreTask. It returns the parent of the task.
+func (r *TaskRepository) DeleteTask(ctx context.Context, userID int, task_id string, reparent bool) (int, error) {
+	tx, err := r.DataBase.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := setActor(ctx, tx, userID); err != nil {
+		return 0, err
+	}
+
+	query := `SELECT COALESCE(parent_id, 0) FROM tasks WHERE id = $1 AND ` + liveTask + ` AND ` + fmt.Sprintf(editableByUser, 2) + ` FOR UPDATE`
+	var parentID int
+	if err := tx.QueryRow(ctx, query, task_id, userID).Scan(&parentID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, domain.TaskNotFound
+		}
+		return 0, err
+	}
+
+	if reparent {
+		query = `UPDATE tasks SET parent_id = NULLIF($2, 0) WHERE parent_id = $1 AND ` + liveTask
+		if _, err := tx.Exec(ctx, query, task_id, parentID); err != nil {
+			return 0, err
+		}
+	}
+
+	query = `WITH RECURSIVE subtree AS (
+		SELECT $1::int AS id
+		UNION
+		SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+	)
+	UPDATE tasks SET deleted_at = CURRENT_TIMESTAMP WHERE id IN (SELECT id FROM subtree)`
+	if _, err := tx.Exec(ctx, query, task_id); err != nil {
+		return 0, err
+	}
+
+	return parentID, tx.Commit(ctx)
+}
+
+// ClearTasks moves stale tasks to the trash. Subtasks that are not stale
+// themselves are moved up to their closest remaining ancestor instead.
 func (r *TaskRepository) ClearTasks(ctx context.Context) error {
-	query := `DELETE FROM tasks WHERE status != 'done' AND due_date < CURRENT_DATE - INTERVAL '7 days'`
-	if _, err := r.DataBase.Exec(ctx, query); err != nil {
+	tx, err := r.DataBase.Begin(ctx)
+	if err != nil {
 		return err
 	}
-	return nil
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	// Every pass lifts the kept subtasks of stale tasks one level up.
+	query := `UPDATE tasks c SET parent_id = p.parent_id FROM tasks p
+	WHERE c.parent_id = p.id AND c.deleted_at IS NULL AND p.deleted_at IS NULL AND NOT ` + staleTask("c.") + ` AND ` + staleTask("p.")
+	for {
+		tag, err := tx.Exec(ctx, query)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			break
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE tasks SET deleted_at = CURRENT_TIMESTAMP WHERE `+liveTask+` AND `+staleTask("")); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *TaskRepository) GetAnalyticsScopes(ctx context.Context) ([]domain.TaskScope, error) {
+	query := `SELECT DISTINCT user_id, 0 FROM tasks WHERE user_id IS NOT NULL AND ` + liveTask + `
+	UNION SELECT DISTINCT 0, project_id FROM tasks WHERE project_id IS NOT NULL AND ` + liveTask
+	rows, err := r.DataBase.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	scopes := make([]domain.TaskScope, 0)
+	for rows.Next() {
+		var scope domain.TaskScope
+		if err := rows.Scan(&scope.UserID, &scope.ProjectID); err != nil {
+			return nil, err
+		}
+
+		scopes = append(scopes, scope)
+	}
+
+	return scopes, rows.Err()
 }
 
-func (r *TaskRepository) GetCachedAnalytics(ctx context.Context) (*domain.Analyse, error) {
-	val, err := r.Cache.Get(ctx, "analytics").Result()
+func analyticsKey(scope domain.TaskScope) string {
+	return "analytics:" + scope.Key()
+}
+
+func (r *TaskRepository) GetCachedAnalytics(ctx context.Context, scope domain.TaskScope) (*domain.Analyse, error) {
+	val, err := r.Cache.Get(ctx, analyticsKey(scope)).Result()
 	if err != nil && err != redis.Nil {
 		return nil, err
 	}
 
 	if err == redis.Nil {
-		return r.GetAnalytics(ctx)
+		return r.GetAnalytics(ctx, scope)
 	}
 	task := &domain.Analyse{}
 	err = json.Unmarshal([]byte(val), task)
@@ -144,47 +475,51 @@ func (r *TaskRepository) GetCachedAnalytics(ctx context.Context) (*domain.Analys
 	return task, nil
 }
 
-func (r *TaskRepository) GetAnalytics(ctx context.Context) (*domain.Analyse, error) {
+func (r *TaskRepository) GetAnalytics(ctx context.Context, scope domain.TaskScope) (*domain.Analyse, error) {
+	condition, scopeArg := scopeCondition(scope)
+	condition = liveTask + ` AND ` + condition
 	var analyse domain.Analyse
 	var week domain.WeeklyReport
 
-	query := `SELECT COUNT(*) FROM tasks WHERE status = 'done' AND due_date >= CURRENT_DATE - INTERVAL '7 days'`
-	err := r.DataBase.QueryRow(ctx, query).Scan(&week.Completed)
+	query := `SELECT COUNT(*) FROM tasks WHERE ` + condition + ` AND ` + doneTask("") + ` AND due_date >= CURRENT_DATE - INTERVAL '7 days'`
+	err := r.DataBase.QueryRow(ctx, query, scopeArg).Scan(&week.Completed)
 	if err != nil {
 		return nil, err
 	}
 
-	query = `SELECT COUNT(*) FROM tasks WHERE status != 'done' AND due_date >= CURRENT_DATE - INTERVAL '7 days'`
-	err = r.DataBase.QueryRow(ctx, query).Scan(&week.Uncompleted)
+	query = `SELECT COUNT(*) FROM tasks WHERE ` + condition + ` AND ` + openTask("") + ` AND due_date >= CURRENT_DATE - INTERVAL '7 days'`
+	err = r.DataBase.QueryRow(ctx, query, scopeArg).Scan(&week.Uncompleted)
 	if err != nil {
 		return nil, err
 	}
 
 	analyse.Weekly = week
 
-	query = `SELECT status, COUNT(*) FROM tasks GROUP BY status`
-	rows, err := r.DataBase.Query(ctx, query)
+	query = `SELECT status, COALESCE(status_category(project_id, status)::text, ''), COUNT(*) FROM tasks WHERE ` + condition + ` GROUP BY project_id, status`
+	rows, err := r.DataBase.Query(ctx, query, scopeArg)
 	if err != nil {
 		return nil, err
 	}
 
 	defer rows.Close()
 
+	analyse.Statuses = make(map[string]int)
 	for rows.Next() {
-		var status string
+		var status, category string
 		var count int
-		err := rows.Scan(&status, &count)
+		err := rows.Scan(&status, &category, &count)
 		if err != nil {
 			return nil, err
 		}
 
-		switch status {
-		case "done":
-			analyse.Done = count
-		case "in_progress":
-			analyse.InProgress = count
-		case "pending":
-			analyse.Pending = count
+		analyse.Statuses[status] += count
+		switch category {
+		case domain.StatusCategoryTodo:
+			analyse.Todo += count
+		case domain.StatusCategoryInProgress:
+			analyse.InProgress += count
+		case domain.StatusCategoryDone:
+			analyse.Done += count
 		}
 	}
 
@@ -192,9 +527,9 @@ func (r *TaskRepository) GetAnalytics(ctx context.Context) (*domain.Analyse, err
 		return nil, err
 	}
 
-	query = `SELECT AVG(created_at - due_date) FROM tasks WHERE status = 'done'`
+	query = `SELECT AVG(created_at - due_date) FROM tasks WHERE ` + condition + ` AND ` + doneTask("")
 	var avgTime sql.NullFloat64
-	err = r.DataBase.QueryRow(ctx, query).Scan(&avgTime)
+	err = r.DataBase.QueryRow(ctx, query, scopeArg).Scan(&avgTime)
 	if err != nil {
 		return nil, err
 	}
@@ -208,13 +543,13 @@ func (r *TaskRepository) GetAnalytics(ctx context.Context) (*domain.Analyse, err
 	return &analyse, nil
 }
 
-func (r *TaskRepository) SetAnalytics(ctx context.Context, task *domain.Analyse) error {
+func (r *TaskRepository) SetAnalytics(ctx context.Context, scope domain.TaskScope, task *domain.Analyse) error {
 	data, err := json.Marshal(*task)
 	if err != nil {
 		return err
 	}
 
-	err = r.Cache.Set(ctx, "analytics", data, 0).Err()
+	err = r.Cache.Set(ctx, analyticsKey(scope), data, 0).Err()
 	if err != nil {
 		return err
 	}
@@ -228,9 +563,21 @@ func (r *TaskRepository) ImportTasks(ctx context.Context, task []*domain.Task) e
 	}
 
 	for _, t := range task {
-		_, err := tx.Exec(ctx, `INSERT INTO tasks (title, description, status, priority, due_date) VALUES ($1, $2, $3, $4, $5)`, t.Title, t.Description, t.Status, t.Priority, t.Due_date)
+		var id int
+		err := setActor(ctx, tx, t.UserID)
+		if err == nil {
+			err = tx.QueryRow(ctx, `INSERT INTO tasks (title, description, status, priority, due_date, user_id, project_id, auto_complete) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, 0), $8) RETURNING id`, t.Title, t.Description, t.Status, t.Priority, t.Due_date, t.UserID, t.ProjectID, t.AutoComplete).Scan(&id)
+		}
+		if err == nil {
+			err = setTaskLabels(ctx, tx, id, t.Labels)
+		}
+		if err == nil {
+			err = setTaskReminders(ctx, tx, id, t.Reminders)
+		}
 		if err != nil {
-			err = tx.Rollback(ctx)
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				return rbErr
+			}
 			return err
 		}
 	}
@@ -243,9 +590,10 @@ func (r *TaskRepository) ImportTasks(ctx context.Context, task []*domain.Task) e
 	return nil
 }
 
-func (r *TaskRepository) ExportTasks(ctx context.Context) ([]*domain.Task, error) {
-	query := `SELECT id, title, description, status, priority, due_date, created_at, updated_at FROM tasks`
-	rows, err := r.DataBase.Query(ctx, query)
+func (r *TaskRepository) ExportTasks(ctx context.Context, scope domain.TaskScope) ([]*domain.Task, error) {
+	condition, scopeArg := scopeCondition(scope)
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE ` + liveTask + ` AND ` + condition
+	rows, err := r.DataBase.Query(ctx, query, scopeArg)
 	if err != nil {
 		return nil, err
 	}
@@ -255,13 +603,20 @@ func (r *TaskRepository) ExportTasks(ctx context.Context) ([]*domain.Task, error
 	tasks := make([]*domain.Task, 0)
 	for rows.Next() {
 		task := &domain.Task{}
-		err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.Status, &task.Priority, &task.Due_date, &task.CreatedAt, &task.UpdatedAt)
-		if err != nil {
+		if err := scanTask(rows, task); err != nil {
 			return nil, err
 		}
 
 		tasks = append(tasks, task)
 	}
 
-	return tasks, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadDetails(ctx, tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, r.loadComments(ctx, tasks)
 }
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+type TokenRepository struct {
+	Cache *redis.Client
+}
+
+func NewTokenRepository(cache *redis.Client) TokenRepositoryInterface {
+	return &TokenRepository{Cache: cache}
+}
+
+func refreshFamilyKey(family string) string {
+	return "refresh:family:" + family
+}
+
+func sessionKey(family string) string {
+	return "refresh:session:" + family
+}
+
+func userFamiliesKey(userID int) string {
+	return "refresh:user:" + strconv.Itoa(userID)
+}
+
+func revokedTokenKey(tokenID string) string {
+	return "revoked:" + tokenID
+}
+
+// rotateScript swaps the current token of a family only if the presented one
+// is still current. A stale token means it was already exchanged, so the
+// whole family is revoked together with its session. A rotation extends the
+// set of families of the user with the family, so that it keeps listing
+// every family still alive.
+var rotateScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if not current then
+	return 0
+end
+if current ~= ARGV[1] then
+	redis.call("DEL", KEYS[1], KEYS[2])
+	redis.call("SREM", KEYS[3], ARGV[4])
+	return -1
+end
+redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+redis.call("PEXPIRE", KEYS[3], ARGV[3])
+return 1
+`)
+
+// CreateRefreshFamily starts a refresh token family together with the
+// session describing it.
+func (r *TokenRepository) CreateRefreshFamily(ctx context.Context, session *domain.Session, tokenID string, ttl time.Duration) error {
+	pipe := r.Cache.TxPipeline()
+	pipe.Set(ctx, refreshFamilyKey(session.ID), tokenID, ttl)
+	pipe.HSet(ctx, sessionKey(session.ID),
+		"user_id", session.UserID,
+		"ip", session.IP,
+		"user_agent", session.UserAgent,
+		"created_at", session.CreatedAt.Unix(),
+		"last_seen_at", session.LastSeenAt.Unix(),
+	)
+	pipe.Expire(ctx, sessionKey(session.ID), ttl)
+	pipe.SAdd(ctx, userFamiliesKey(session.UserID), session.ID)
+	pipe.Expire(ctx, userFamiliesKey(session.UserID), ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *TokenRepository) RotateRefreshFamily(ctx context.Context, userID int, family string, oldTokenID, newTokenID string, ttl time.Duration) error {
+	keys := []string{refreshFamilyKey(family), sessionKey(family), userFamiliesKey(userID)}
+	res, err := rotateScript.Run(ctx, r.Cache, keys, oldTokenID, newTokenID, ttl.Milliseconds(), family).Int()
+	if err != nil {
+		return err
+	}
+
+	switch res {
+	case 1:
+		return nil
+	case -1:
+		return domain.TokenReused
+	default:
+		return domain.InvalidToken
+	}
+}
+
+// TouchSession records a refresh of the session and extends it with the family.
+func (r *TokenRepository) TouchSession(ctx context.Context, family string, ip string, seenAt time.Time, ttl time.Duration) error {
+	pipe := r.Cache.TxPipeline()
+	pipe.HSet(ctx, sessionKey(family), "ip", ip, "last_seen_at", seenAt.Unix())
+	pipe.Expire(ctx, sessionKey(family), ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *TokenRepository) GetSession(ctx context.Context, family string) (*domain.Session, error) {
+	fields, err := r.Cache.HGetAll(ctx, sessionKey(family)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, domain.SessionNotFound
+	}
+
+	return sessionFromFields(family, fields), nil
+}
+
+// GetSessions returns the sessions of the user and forgets the ones that
+// have expired.
+func (r *TokenRepository) GetSessions(ctx context.Context, userID int) ([]*domain.Session, error) {
+	families, err := r.Cache.SMembers(ctx, userFamiliesKey(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := r.Cache.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, 0, len(families))
+	for _, family := range families {
+		cmds = append(cmds, pipe.HGetAll(ctx, sessionKey(family)))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*domain.Session, 0, len(families))
+	var expired []any
+	for i, cmd := range cmds {
+		fields := cmd.Val()
+		if len(fields) == 0 {
+			expired = append(expired, families[i])
+			continue
+		}
+		sessions = append(sessions, sessionFromFields(families[i], fields))
+	}
+
+	if len(expired) > 0 {
+		if err := r.Cache.SRem(ctx, userFamiliesKey(userID), expired...).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return sessions, nil
+}
+
+func sessionFromFields(family string, fields map[string]string) *domain.Session {
+	userID, _ := strconv.Atoi(fields["user_id"])
+	createdAt, _ := strconv.ParseInt(fields["created_at"], 10, 64)
+	lastSeenAt, _ := strconv.ParseInt(fields["last_seen_at"], 10, 64)
+
+	return &domain.Session{
+		ID:         family,
+		UserID:     userID,
+		IP:         fields["ip"],
+		UserAgent:  fields["user_agent"],
+		CreatedAt:  time.Unix(createdAt, 0),
+		LastSeenAt: time.Unix(lastSeenAt, 0),
+	}
+}
+
+func (r *TokenRepository) DeleteRefreshFamily(ctx context.Context, userID int, family string) error {
+	pipe := r.Cache.TxPipeline()
+	pipe.Del(ctx, refreshFamilyKey(family), sessionKey(family))
+	pipe.SRem(ctx, userFamiliesKey(userID), family)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *TokenRepository) DeleteUserRefreshFamilies(ctx context.Context, userID int) error {
+	families, err := r.Cache.SMembers(ctx, userFamiliesKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, 2*len(families)+1)
+	for _, family := range families {
+		keys = append(keys, refreshFamilyKey(family), sessionKey(family))
+	}
+	keys = append(keys, userFamiliesKey(userID))
+
+	return r.Cache.Del(ctx, keys...).Err()
+}
+
+func (r *TokenRepository) RevokeToken(ctx context.Context, tokenID string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+	return r.Cache.Set(ctx, revokedTokenKey(tokenID), 1, ttl).Err()
+}
+
+// IsTokenRevoked reports whether the token itself was revoked or the family it
+// belongs to no longer exists.
+func (r *TokenRepository) IsTokenRevoked(ctx context.Context, tokenID string, family string) (bool, error) {
+	pipe := r.Cache.Pipeline()
+	revoked := pipe.Exists(ctx, revokedTokenKey(tokenID))
+	active := pipe.Exists(ctx, refreshFamilyKey(family))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+
+	return revoked.Val() > 0 || active.Val() == 0, nil
+}
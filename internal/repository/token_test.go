@@ -0,0 +1,78 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/repository"
+)
+
+func newTokenRepository(t *testing.T) (repository.TokenRepositoryInterface, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return repository.NewTokenRepository(client), server
+}
+
+func TestRotateRefreshFamilyReuseRevokesSession(t *testing.T) {
+	repo, _ := newTokenRepository(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, family := range []string{"reused", "other"} {
+		session := &domain.Session{ID: family, UserID: 1, IP: "10.0.0.1", CreatedAt: now, LastSeenAt: now}
+		require.NoError(t, repo.CreateRefreshFamily(ctx, session, family+"-1", time.Hour))
+	}
+
+	require.NoError(t, repo.RotateRefreshFamily(ctx, 1, "reused", "reused-1", "reused-2", time.Hour))
+	err := repo.RotateRefreshFamily(ctx, 1, "reused", "reused-1", "reused-3", time.Hour)
+	assert.ErrorIs(t, err, domain.TokenReused)
+
+	sessions, err := repo.GetSessions(ctx, 1)
+	require.NoError(t, err)
+	if assert.Len(t, sessions, 1) {
+		assert.Equal(t, "other", sessions[0].ID)
+	}
+
+	_, err = repo.GetSession(ctx, "reused")
+	assert.ErrorIs(t, err, domain.SessionNotFound)
+
+	revoked, err := repo.IsTokenRevoked(ctx, "access", "reused")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	// The revoked family cannot be refreshed with its latest token either.
+	err = repo.RotateRefreshFamily(ctx, 1, "reused", "reused-2", "reused-4", time.Hour)
+	assert.ErrorIs(t, err, domain.InvalidToken)
+}
+
+func TestRotatedFamilyOutlivesLoginTTL(t *testing.T) {
+	repo, server := newTokenRepository(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	session := &domain.Session{ID: "family", UserID: 1, CreatedAt: now, LastSeenAt: now}
+	require.NoError(t, repo.CreateRefreshFamily(ctx, session, "token-1", time.Hour))
+
+	// Refreshes keep the family alive past the TTL it got at login.
+	server.FastForward(40 * time.Minute)
+	require.NoError(t, repo.RotateRefreshFamily(ctx, 1, "family", "token-1", "token-2", time.Hour))
+	require.NoError(t, repo.TouchSession(ctx, "family", "10.0.0.1", time.Now(), time.Hour))
+	server.FastForward(40 * time.Minute)
+
+	sessions, err := repo.GetSessions(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, sessions, 1)
+
+	require.NoError(t, repo.DeleteUserRefreshFamilies(ctx, 1))
+
+	revoked, err := repo.IsTokenRevoked(ctx, "access", "family")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
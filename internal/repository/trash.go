@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+// GetTrash returns the trashed tasks of the scope, most recent first.
+// Subtasks trashed together with their parent are left out, they are
+// restored with it.
+func (r *TaskRepository) GetTrash(ctx context.Context, scope domain.TaskScope) ([]*domain.Task, error) {
+	condition, scopeArg := scopeCondition(scope)
+	query := `SELECT ` + taskColumns + ` FROM tasks t WHERE deleted_at IS NOT NULL AND ` + condition + `
+	AND NOT EXISTS (SELECT 1 FROM tasks p WHERE p.id = t.parent_id AND p.deleted_at = t.deleted_at)
+	ORDER BY deleted_at DESC, id`
+
+	rows, err := r.DataBase.Query(ctx, query, scopeArg)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	tasks := make([]*domain.Task, 0)
+	for rows.Next() {
+		task := &domain.Task{}
+		if err := scanTask(rows, task); err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tasks, r.loadDetails(ctx, tasks)
+}
+
+// RestoreTask takes the task out of the trash together with the subtasks
+// trashed with it. A task whose parent is still in the trash is moved to the
+// top level.
+func (r *TaskRepository) RestoreTask(ctx context.Context, userID, taskID int) (*domain.Task, error) {
+	tx, err := r.DataBase.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := setActor(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	query := `WITH RECURSIVE subtree AS (
+		SELECT id, deleted_at FROM tasks WHERE id = $1 AND deleted_at IS NOT NULL AND ` + fmt.Sprintf(editableByUser, 2) + `
+		UNION
+		SELECT t.id, t.deleted_at FROM tasks t JOIN subtree s ON t.parent_id = s.id AND t.deleted_at = s.deleted_at
+	)
+	UPDATE tasks SET deleted_at = NULL WHERE id IN (SELECT id FROM subtree)`
+	tag, err := tx.Exec(ctx, query, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, domain.TaskNotFound
+	}
+
+	query = `UPDATE tasks SET parent_id = NULL WHERE id = $1 AND parent_id IN (SELECT id FROM tasks WHERE deleted_at IS NOT NULL)`
+	if _, err := tx.Exec(ctx, query, taskID); err != nil {
+		return nil, err
+	}
+
+	task := &domain.Task{}
+	if err := scanTask(tx.QueryRow(ctx, `SELECT `+taskColumns+` FROM tasks WHERE id = $1`, taskID), task); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return task, r.loadDetails(ctx, []*domain.Task{task})
+}
+
+// PurgeTasks permanently deletes the tasks trashed longer than olderThan ago
+// and returns their number. Subtasks outside the trash are moved to the top level
+// rather than cascaded.
+func (r *TaskRepository) PurgeTasks(ctx context.Context, olderThan time.Duration) (int64, error) {
+	const expired = `deleted_at < CURRENT_TIMESTAMP - $1::int * INTERVAL '1 second'`
+	seconds := int(olderThan.Seconds())
+
+	tx, err := r.DataBase.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	query := `UPDATE tasks SET parent_id = NULL WHERE ` + liveTask + ` AND parent_id IN (SELECT id FROM tasks WHERE ` + expired + `)`
+	if _, err := tx.Exec(ctx, query, seconds); err != nil {
+		return 0, err
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM tasks WHERE `+expired, seconds)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), tx.Commit(ctx)
+}
@@ -2,11 +2,22 @@ package repository
 
 import (
 	"context"
+	"errors"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/wazwki/skillsrock/internal/domain"
 )
 
+const userColumns = `id, name, COALESCE(password, ''), role, disabled_at IS NOT NULL, password_reset_required, totp_enabled,
+	COALESCE(display_name, ''), COALESCE(email, ''), timezone, locale, COALESCE(created_at, CURRENT_TIMESTAMP)`
+
+func scanUser(row pgx.Row, user *domain.User) error {
+	return row.Scan(&user.ID, &user.Name, &user.Password, &user.Role, &user.Disabled, &user.PasswordResetRequired, &user.MFAEnabled,
+		&user.DisplayName, &user.Email, &user.Timezone, &user.Locale, &user.CreatedAt)
+}
+
 type UserRepository struct {
 	DataBase *pgxpool.Pool
 }
@@ -16,10 +27,14 @@ func NewUserRepository(db *pgxpool.Pool) UserRepositoryInterface {
 }
 
 func (r *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
-	query := `INSERT INTO users (name, password) VALUES ($1, $2) RETURNING id`
+	query := `INSERT INTO users (name, password) VALUES ($1, $2) RETURNING id, role`
 
-	err := r.DataBase.QueryRow(ctx, query, user.Name, user.Password).Scan(&user.ID)
+	err := r.DataBase.QueryRow(ctx, query, user.Name, user.Password).Scan(&user.ID, &user.Role)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, domain.UserAlreadyExists
+		}
 		return nil, err
 	}
 
@@ -27,13 +42,156 @@ func (r *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*do
 }
 
 func (r *UserRepository) CheckUser(ctx context.Context, user *domain.User) (*domain.User, error) {
-	query := `SELECT id, name, password FROM users WHERE name = $1`
+	query := `SELECT ` + userColumns + ` FROM users WHERE name = $1`
+
+	dbUser := &domain.User{}
+	err := scanUser(r.DataBase.QueryRow(ctx, query, user.Name), dbUser)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.UserNotFound
+		}
+		return nil, err
+	}
+
+	return dbUser, nil
+}
+
+func (r *UserRepository) GetUser(ctx context.Context, userID int) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+
+	user := &domain.User{}
+	err := scanUser(r.DataBase.QueryRow(ctx, query, userID), user)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.UserNotFound
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
 
-	var dbUser domain.User
-	err := r.DataBase.QueryRow(ctx, query, user.Name).Scan(&dbUser.ID, &dbUser.Name, &dbUser.Password)
+func (r *UserRepository) GetUsers(ctx context.Context) ([]*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users ORDER BY id`
+	rows, err := r.DataBase.Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	return &dbUser, nil
+	defer rows.Close()
+
+	users := make([]*domain.User, 0)
+	for rows.Next() {
+		user := &domain.User{}
+		if err := scanUser(rows, user); err != nil {
+			return nil, err
+		}
+		// Password hashes never leave the repository in listings.
+		user.Password = ""
+
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+func (r *UserRepository) SetUserRole(ctx context.Context, userID int, role string) error {
+	query := `UPDATE users SET role = $2 WHERE id = $1`
+
+	return r.execUser(ctx, query, userID, role)
+}
+
+func (r *UserRepository) SetUserDisabled(ctx context.Context, userID int, disabled bool) error {
+	query := `UPDATE users SET disabled_at = CASE WHEN $2 THEN COALESCE(disabled_at, CURRENT_TIMESTAMP) END WHERE id = $1`
+
+	return r.execUser(ctx, query, userID, disabled)
+}
+
+func (r *UserRepository) SetPasswordResetRequired(ctx context.Context, userID int, required bool) error {
+	query := `UPDATE users SET password_reset_required = $2 WHERE id = $1`
+
+	return r.execUser(ctx, query, userID, required)
+}
+
+// UpdatePassword also lifts a forced password reset.
+func (r *UserRepository) UpdatePassword(ctx context.Context, userID int, password string) error {
+	query := `UPDATE users SET password = $2, password_reset_required = FALSE WHERE id = $1`
+
+	return r.execUser(ctx, query, userID, password)
+}
+
+func (r *UserRepository) UpdateProfile(ctx context.Context, user *domain.User) (*domain.User, error) {
+	query := `UPDATE users SET display_name = NULLIF($2, ''), email = NULLIF($3, ''), timezone = $4, locale = $5
+	WHERE id = $1 RETURNING ` + userColumns
+
+	updated := &domain.User{}
+	err := scanUser(r.DataBase.QueryRow(ctx, query, user.ID, user.DisplayName, user.Email, user.Timezone, user.Locale), updated)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.UserNotFound
+		}
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteUser removes the account with its personal tasks, API keys and
+// memberships. Projects it owns are handed over to the longest-standing other
+// owner, and deleted together with their tasks if there is none. Its tasks
+// and series in the remaining projects are handed over to the project owner.
+func (r *UserRepository) DeleteUser(ctx context.Context, userID int) error {
+	tx, err := r.DataBase.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	query := `UPDATE projects p SET owner_id = m.user_id FROM (
+		SELECT DISTINCT ON (project_id) project_id, user_id FROM project_members
+		WHERE user_id <> $1 AND role = 'owner' ORDER BY project_id, created_at
+	) m WHERE p.id = m.project_id AND p.owner_id = $1`
+	if _, err := tx.Exec(ctx, query, userID); err != nil {
+		return err
+	}
+
+	for _, table := range []string{"tasks", "task_series"} {
+		query = `UPDATE ` + table + ` t SET user_id = p.owner_id FROM projects p
+		WHERE t.project_id = p.id AND t.user_id = $1 AND p.owner_id <> $1`
+		if _, err := tx.Exec(ctx, query, userID); err != nil {
+			return err
+		}
+	}
+
+	query = `DELETE FROM users WHERE id = $1`
+	res, err := tx.Exec(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return domain.UserNotFound
+	}
+
+	return tx.Commit(ctx)
+}
+
+// RehashPassword replaces the hash only if it was not changed meanwhile.
+func (r *UserRepository) RehashPassword(ctx context.Context, userID int, oldPassword, newPassword string) error {
+	query := `UPDATE users SET password = $3 WHERE id = $1 AND password = $2`
+
+	_, err := r.DataBase.Exec(ctx, query, userID, oldPassword, newPassword)
+	return err
+}
+
+func (r *UserRepository) execUser(ctx context.Context, query string, userID int, arg any) error {
+	res, err := r.DataBase.Exec(ctx, query, userID, arg)
+	if err != nil {
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return domain.UserNotFound
+	}
+
+	return nil
 }
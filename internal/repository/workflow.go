@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/wazwki/skillsrock/internal/domain"
+)
+
+type WorkflowRepository struct {
+	DataBase *pgxpool.Pool
+}
+
+func NewWorkflowRepository(db *pgxpool.Pool) WorkflowRepositoryInterface {
+	return &WorkflowRepository{DataBase: db}
+}
+
+// GetWorkflow returns the workflow of the project, the default workflow for
+// projects without their own and for projectID 0.
+func (r *WorkflowRepository) GetWorkflow(ctx context.Context, projectID int) (*domain.Workflow, error) {
+	workflow := &domain.Workflow{ProjectID: projectID}
+
+	query := `SELECT name, category, project_id IS NOT NULL FROM task_statuses
+	WHERE project_id IS NOT DISTINCT FROM workflow_of(NULLIF($1, 0)) ORDER BY position`
+	rows, err := r.DataBase.Query(ctx, query, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow.Statuses = make([]*domain.WorkflowStatus, 0)
+	for rows.Next() {
+		status := &domain.WorkflowStatus{}
+		if err := rows.Scan(&status.Name, &status.Category, &workflow.Custom); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		workflow.Statuses = append(workflow.Statuses, status)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	query = `SELECT f.name, t.name FROM task_transitions tr
+	JOIN task_statuses f ON f.id = tr.from_id JOIN task_statuses t ON t.id = tr.to_id
+	WHERE f.project_id IS NOT DISTINCT FROM workflow_of(NULLIF($1, 0)) ORDER BY f.position, t.position`
+	rows, err = r.DataBase.Query(ctx, query, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	workflow.Transitions = make([]*domain.WorkflowTransition, 0)
+	for rows.Next() {
+		transition := &domain.WorkflowTransition{}
+		if err := rows.Scan(&transition.From, &transition.To); err != nil {
+			return nil, err
+		}
+		workflow.Transitions = append(workflow.Transitions, transition)
+	}
+
+	return workflow, rows.Err()
+}
+
+// statusInUse returns StatusInUse when a task of the project, or of the
+// default workflow for projectID 0, trashed ones included, has a status
+// missing from names.
+func statusInUse(ctx context.Context, tx pgx.Tx, projectID int, names []string) error {
+	condition, args := `project_id = $2`, []any{names, projectID}
+	if projectID == 0 {
+		condition, args = `workflow_of(project_id) IS NULL`, []any{names}
+	}
+
+	var status string
+	query := `SELECT status FROM tasks WHERE status <> ALL($1) AND ` + condition + ` LIMIT 1`
+	err := tx.QueryRow(ctx, query, args...).Scan(&status)
+	if err == nil {
+		return fmt.Errorf("%w: %s", domain.StatusInUse, status)
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	return err
+}
+
+// lockWorkflow serialises changes of the workflow of the project.
+func lockWorkflow(ctx context.Context, tx pgx.Tx, projectID int) error {
+	_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('task_statuses'), $1::int)`, projectID)
+	return err
+}
+
+// SetWorkflow replaces the workflow of the project, or the default workflow
+// for ProjectID 0. Statuses still used by tasks cannot be left out.
+func (r *WorkflowRepository) SetWorkflow(ctx context.Context, workflow *domain.Workflow) (*domain.Workflow, error) {
+	tx, err := r.DataBase.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := lockWorkflow(ctx, tx, workflow.ProjectID); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(workflow.Statuses))
+	for _, status := range workflow.Statuses {
+		names = append(names, status.Name)
+	}
+	if err := statusInUse(ctx, tx, workflow.ProjectID, names); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM task_statuses WHERE project_id IS NOT DISTINCT FROM NULLIF($1, 0)`, workflow.ProjectID); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]int, len(workflow.Statuses))
+	for position, status := range workflow.Statuses {
+		var id int
+		query := `INSERT INTO task_statuses (project_id, name, category, position) VALUES (NULLIF($1, 0), $2, $3, $4) RETURNING id`
+		if err := tx.QueryRow(ctx, query, workflow.ProjectID, status.Name, status.Category, position).Scan(&id); err != nil {
+			return nil, err
+		}
+		ids[status.Name] = id
+	}
+
+	for _, transition := range workflow.Transitions {
+		query := `INSERT INTO task_transitions (from_id, to_id) VALUES ($1, $2)`
+		if _, err := tx.Exec(ctx, query, ids[transition.From], ids[transition.To]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return r.GetWorkflow(ctx, workflow.ProjectID)
+}
+
+// DeleteWorkflow returns the project to the default workflow, which has to
+// cover the statuses of its tasks.
+func (r *WorkflowRepository) DeleteWorkflow(ctx context.Context, projectID int) error {
+	tx, err := r.DataBase.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if err := lockWorkflow(ctx, tx, projectID); err != nil {
+		return err
+	}
+
+	var names []string
+	if err := tx.QueryRow(ctx, `SELECT COALESCE(array_agg(name), '{}') FROM task_statuses WHERE project_id IS NULL`).Scan(&names); err != nil {
+		return err
+	}
+	if err := statusInUse(ctx, tx, projectID, names); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM task_statuses WHERE project_id = $1`, projectID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/repository"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type AdminService struct {
+	users  repository.UserRepositoryInterface
+	tokens repository.TokenRepositoryInterface
+}
+
+func NewAdminService(users repository.UserRepositoryInterface, tokens repository.TokenRepositoryInterface) AdminServiceInterface {
+	return &AdminService{users: users, tokens: tokens}
+}
+
+// requireAdmin returns the ID of the calling admin. Admin actions are not
+// available to API keys.
+func requireAdmin(ctx context.Context) (int, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return 0, err
+	}
+
+	if !domain.UserRoleAtLeast(domain.RoleFromContext(ctx), domain.UserRoleAdmin) {
+		return 0, domain.Forbidden
+	}
+
+	return userID, nil
+}
+
+func (s *AdminService) GetUsers(ctx context.Context) ([]*domain.User, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	users, err := s.users.GetUsers(ctx)
+	if err != nil {
+		logger.Error("Failed to get users", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (s *AdminService) SetUserRole(ctx context.Context, userID int, role string) error {
+	adminID, err := requireAdmin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !domain.ValidUserRole(role) {
+		return domain.InvalidUserRole
+	}
+
+	// Admins cannot demote themselves and leave the system without an admin.
+	if userID == adminID && role != domain.UserRoleAdmin {
+		return domain.Forbidden
+	}
+
+	if err := s.users.SetUserRole(ctx, userID, role); err != nil {
+		if !errors.Is(err, domain.UserNotFound) {
+			logger.Error("Failed to set user role", zap.Error(err), zap.String("module", "skillsrock"))
+		}
+		return err
+	}
+
+	// Tokens carry the role, so the sessions end for the new one to apply.
+	return s.endSessions(ctx, userID)
+}
+
+// DisableUser blocks the account and ends all of its sessions.
+func (s *AdminService) DisableUser(ctx context.Context, userID int) error {
+	adminID, err := requireAdmin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if userID == adminID {
+		return domain.Forbidden
+	}
+
+	if err := s.users.SetUserDisabled(ctx, userID, true); err != nil {
+		if !errors.Is(err, domain.UserNotFound) {
+			logger.Error("Failed to disable user", zap.Error(err), zap.String("module", "skillsrock"))
+		}
+		return err
+	}
+
+	return s.endSessions(ctx, userID)
+}
+
+func (s *AdminService) EnableUser(ctx context.Context, userID int) error {
+	if _, err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	if err := s.users.SetUserDisabled(ctx, userID, false); err != nil {
+		if !errors.Is(err, domain.UserNotFound) {
+			logger.Error("Failed to enable user", zap.Error(err), zap.String("module", "skillsrock"))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ForcePasswordReset ends all sessions of the user and blocks login until
+// the password is reset.
+func (s *AdminService) ForcePasswordReset(ctx context.Context, userID int) error {
+	if _, err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	if err := s.users.SetPasswordResetRequired(ctx, userID, true); err != nil {
+		if !errors.Is(err, domain.UserNotFound) {
+			logger.Error("Failed to require password reset", zap.Error(err), zap.String("module", "skillsrock"))
+		}
+		return err
+	}
+
+	return s.endSessions(ctx, userID)
+}
+
+func (s *AdminService) endSessions(ctx context.Context, userID int) error {
+	if err := s.tokens.DeleteUserRefreshFamilies(ctx, userID); err != nil {
+		logger.Error("Failed to delete refresh token families", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	return nil
+}
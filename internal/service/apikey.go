@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/repository"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// API keys look like sr_<prefix>_<secret>. The prefix is stored in clear to
+// find the key, the whole key only as a SHA-256 hash.
+const apiKeyPrefix = "sr_"
+
+type APIKeyService struct {
+	repo repository.APIKeyRepositoryInterface
+}
+
+func NewAPIKeyService(repo repository.APIKeyRepositoryInterface) APIKeyServiceInterface {
+	return &APIKeyService{repo: repo}
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashSecret is used for high-entropy secrets such as API keys and reset
+// tokens, which do not need a slow password hash.
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// requireSession keeps key management to interactive sessions, so that a
+// leaked key cannot be used to mint or revoke others.
+func requireSession(ctx context.Context) error {
+	if _, ok := domain.ScopesFromContext(ctx); ok {
+		return domain.Forbidden
+	}
+	return nil
+}
+
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, key *domain.APIKey) (*domain.APIKey, string, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return nil, "", err
+	}
+
+	if len(key.Scopes) == 0 {
+		return nil, "", domain.InvalidScope
+	}
+	for _, scope := range key.Scopes {
+		if !domain.ValidScope(scope) {
+			return nil, "", domain.InvalidScope
+		}
+	}
+
+	prefix, err := randomHex(4)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := randomHex(24)
+	if err != nil {
+		return nil, "", err
+	}
+	rawKey := apiKeyPrefix + prefix + "_" + secret
+
+	key.UserID = userID
+	key.Prefix = prefix
+	key.KeyHash = hashSecret(rawKey)
+
+	created, err := s.repo.CreateAPIKey(ctx, key)
+	if err != nil {
+		logger.Error("Failed to create api key", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, "", err
+	}
+
+	return created, rawKey, nil
+}
+
+func (s *APIKeyService) GetAPIKeys(ctx context.Context) ([]*domain.APIKey, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.repo.GetAPIKeys(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get api keys", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, keyID int) error {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return err
+	}
+
+	if err := s.repo.RevokeAPIKey(ctx, userID, keyID); err != nil {
+		if !errors.Is(err, domain.APIKeyNotFound) {
+			logger.Error("Failed to revoke api key", zap.Error(err), zap.String("module", "skillsrock"))
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *APIKeyService) Authenticate(ctx context.Context, rawKey string) (*domain.APIKey, error) {
+	prefix, _, ok := strings.Cut(strings.TrimPrefix(rawKey, apiKeyPrefix), "_")
+	if !ok || !strings.HasPrefix(rawKey, apiKeyPrefix) {
+		return nil, domain.InvalidToken
+	}
+
+	key, err := s.repo.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, domain.APIKeyNotFound) {
+			return nil, domain.InvalidToken
+		}
+		logger.Error("Failed to get api key", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(hashSecret(rawKey))) != 1 {
+		return nil, domain.InvalidToken
+	}
+
+	if err := s.repo.TouchAPIKey(ctx, key.ID); err != nil {
+		logger.Error("Failed to update api key last use", zap.Error(err), zap.String("module", "skillsrock"))
+	}
+
+	return key, nil
+}
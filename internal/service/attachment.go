@@ -0,0 +1,201 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/repository"
+	"github.com/wazwki/skillsrock/pkg/blob"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// orphanBatch is the number of orphaned blobs removed per query.
+const orphanBatch = 100
+
+// cleanupTimeout bounds a run of the cleanup worker, so that a hung database
+// or blob store does not stall it.
+const cleanupTimeout = time.Minute
+
+type AttachmentService struct {
+	repo     repository.AttachmentRepositoryInterface
+	tasks    repository.TaskRepositoryInterface
+	projects repository.ProjectRepositoryInterface
+	blobs    blob.BlobStore
+	limits   domain.AttachmentLimits
+}
+
+func NewAttachmentService(repo repository.AttachmentRepositoryInterface, tasks repository.TaskRepositoryInterface, projects repository.ProjectRepositoryInterface, blobs blob.BlobStore, limits domain.AttachmentLimits) AttachmentServiceInterface {
+	s := &AttachmentService{repo: repo, tasks: tasks, projects: projects, blobs: blobs, limits: limits}
+
+	// Deleting or clearing tasks cascades to their attachments, the worker
+	// removes the blobs left behind.
+	go s.cleanupWorker(time.Minute * 5)
+
+	return s
+}
+
+func (s *AttachmentService) GetAttachments(ctx context.Context, taskID int) ([]*domain.Attachment, error) {
+	if _, err := taskAccess(ctx, s.tasks, s.projects, taskID, domain.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.repo.GetAttachments(ctx, taskID)
+	if err != nil {
+		logger.Error("Failed to get attachments", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// UploadAttachment stores attachment.Size bytes read from r. The content type
+// is detected from the contents.
+func (s *AttachmentService) UploadAttachment(ctx context.Context, attachment *domain.Attachment, r io.Reader) (*domain.Attachment, error) {
+	attachment.Name = domain.AttachmentName(attachment.Name)
+	if attachment.Name == "" || attachment.Size <= 0 {
+		return nil, domain.InvalidAttachment
+	}
+	if attachment.Size > s.limits.MaxSize {
+		return nil, domain.AttachmentTooLarge
+	}
+
+	userID, err := taskAccess(ctx, s.tasks, s.projects, attachment.TaskID, domain.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	attachment.UserID = userID
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	attachment.ContentType = http.DetectContentType(head[:n])
+	if !s.limits.AllowsType(attachment.ContentType) {
+		return nil, domain.UnsupportedAttachmentType
+	}
+
+	key, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	attachment.BlobKey = key[:2] + "/" + key[2:]
+
+	hash := sha256.New()
+	body := io.TeeReader(io.MultiReader(bytes.NewReader(head[:n]), r), hash)
+	if err := s.blobs.Put(ctx, attachment.BlobKey, body, attachment.Size, attachment.ContentType); err != nil {
+		logger.Error("Failed to store attachment", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+	attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	created, err := s.repo.CreateAttachment(ctx, attachment)
+	if err != nil {
+		logger.Error("Failed to create attachment", zap.Error(err), zap.String("module", "skillsrock"))
+		if err := s.blobs.Delete(context.WithoutCancel(ctx), attachment.BlobKey); err != nil {
+			logger.Error("Failed to delete blob", zap.Error(err), zap.String("module", "skillsrock"))
+		}
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// OpenAttachment returns the attachment with its contents, which the caller closes.
+func (s *AttachmentService) OpenAttachment(ctx context.Context, taskID, attachmentID int) (*domain.Attachment, io.ReadCloser, error) {
+	if _, err := taskAccess(ctx, s.tasks, s.projects, taskID, domain.ProjectRoleViewer); err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := s.repo.GetAttachment(ctx, taskID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contents, err := s.blobs.Get(ctx, attachment.BlobKey)
+	if err != nil {
+		logger.Error("Failed to read attachment", zap.Error(err), zap.String("module", "skillsrock"))
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, nil, domain.AttachmentNotFound
+		}
+		return nil, nil, err
+	}
+
+	return attachment, contents, nil
+}
+
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, taskID, attachmentID int) error {
+	if _, err := taskAccess(ctx, s.tasks, s.projects, taskID, domain.ProjectRoleEditor); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteAttachment(ctx, taskID, attachmentID); err != nil {
+		if !errors.Is(err, domain.AttachmentNotFound) {
+			logger.Error("Failed to delete attachment", zap.Error(err), zap.String("module", "skillsrock"))
+		}
+		return err
+	}
+
+	s.removeOrphanedBlobs(context.WithoutCancel(ctx))
+
+	return nil
+}
+
+// removeOrphanedBlobs deletes a batch of blobs no attachment refers to any
+// more and returns how many were removed. Failures are retried later.
+func (s *AttachmentService) removeOrphanedBlobs(ctx context.Context) int {
+	keys, err := s.repo.GetOrphanedBlobs(ctx, orphanBatch)
+	if err != nil {
+		logger.Error("Failed to get orphaned blobs", zap.Error(err), zap.String("module", "skillsrock"))
+		return 0
+	}
+
+	removed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			logger.Error("Failed to delete blob", zap.Error(err), zap.String("module", "skillsrock"))
+			continue
+		}
+		removed = append(removed, key)
+	}
+
+	if len(removed) == 0 {
+		return 0
+	}
+	if err := s.repo.RemoveOrphanedBlobs(ctx, removed); err != nil {
+		logger.Error("Failed to remove orphaned blobs", zap.Error(err), zap.String("module", "skillsrock"))
+		return 0
+	}
+
+	return len(removed)
+}
+
+func (s *AttachmentService) cleanupWorker(updateInterval time.Duration) {
+	tick := time.NewTicker(updateInterval)
+	defer tick.Stop()
+	for range tick.C {
+		s.drainOrphanedBlobs()
+	}
+}
+
+// drainOrphanedBlobs removes orphaned blobs batch by batch until a batch
+// comes back short, which means none are left or the rest keep failing, or
+// until cleanupTimeout runs out. What is left waits for the next run.
+func (s *AttachmentService) drainOrphanedBlobs() {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+
+	for ctx.Err() == nil {
+		if s.removeOrphanedBlobs(ctx) < orphanBatch {
+			break
+		}
+	}
+}
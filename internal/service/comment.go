@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/repository"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type CommentService struct {
+	repo     repository.CommentRepositoryInterface
+	tasks    repository.TaskRepositoryInterface
+	projects repository.ProjectRepositoryInterface
+}
+
+func NewCommentService(repo repository.CommentRepositoryInterface, tasks repository.TaskRepositoryInterface, projects repository.ProjectRepositoryInterface) CommentServiceInterface {
+	return &CommentService{repo: repo, tasks: tasks, projects: projects}
+}
+
+func (s *CommentService) task(ctx context.Context, taskID int, required string) (int, error) {
+	return taskAccess(ctx, s.tasks, s.projects, taskID, required)
+}
+
+// own loads a live comment of the task written by the caller.
+func (s *CommentService) own(ctx context.Context, taskID, commentID int) (*domain.Comment, error) {
+	userID, err := s.task(ctx, taskID, domain.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.repo.GetComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.TaskID != taskID || comment.Deleted {
+		return nil, domain.CommentNotFound
+	}
+	if comment.UserID != userID {
+		return nil, domain.Forbidden
+	}
+
+	return comment, nil
+}
+
+// GetComments returns a page of threads of the task and the number of threads.
+func (s *CommentService) GetComments(ctx context.Context, taskID int, page domain.Page) ([]*domain.Comment, int, error) {
+	if !domain.ValidPage(&page) {
+		return nil, 0, domain.InvalidPage
+	}
+
+	if _, err := s.task(ctx, taskID, domain.ProjectRoleViewer); err != nil {
+		return nil, 0, err
+	}
+
+	comments, total, err := s.repo.GetComments(ctx, taskID, page)
+	if err != nil {
+		logger.Error("Failed to get comments", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, 0, err
+	}
+
+	return domain.BuildCommentThreads(comments), total, nil
+}
+
+func (s *CommentService) CreateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+	if !domain.ValidCommentBody(comment.Body) {
+		return nil, domain.InvalidComment
+	}
+
+	userID, err := s.task(ctx, comment.TaskID, domain.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	comment.UserID = userID
+
+	created, err := s.repo.CreateComment(ctx, comment)
+	if err != nil {
+		if !errors.Is(err, domain.InvalidParentComment) {
+			logger.Error("Failed to create comment", zap.Error(err), zap.String("module", "skillsrock"))
+		}
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateComment changes the body of a comment, authors only.
+func (s *CommentService) UpdateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+	if !domain.ValidCommentBody(comment.Body) {
+		return nil, domain.InvalidComment
+	}
+
+	if _, err := s.own(ctx, comment.TaskID, comment.ID); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.UpdateComment(ctx, comment)
+	if err != nil {
+		logger.Error("Failed to update comment", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteComment deletes a comment, authors only.
+func (s *CommentService) DeleteComment(ctx context.Context, taskID, commentID int) error {
+	if _, err := s.own(ctx, taskID, commentID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteComment(ctx, commentID); err != nil {
+		logger.Error("Failed to delete comment", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func (s *TaskService) GetBlockers(ctx context.Context, taskID int) ([]*domain.Task, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetTask(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	blockers, err := s.repo.GetBlockers(ctx, taskID)
+	if err != nil {
+		logger.Error("Failed to get blockers", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return blockers, nil
+}
+
+// AddDependency marks the task as blocked by another task of the same
+// project. Edges that would close a cycle are refused.
+func (s *TaskService) AddDependency(ctx context.Context, dep domain.TaskDependency) error {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	task, err := s.repo.GetTask(ctx, userID, dep.TaskID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.scope(ctx, task.ProjectID, domain.ProjectRoleEditor); err != nil {
+		return err
+	}
+
+	blocker, err := s.repo.GetTask(ctx, userID, dep.BlockerID)
+	if err != nil {
+		return err
+	}
+	if !sameTaskScope(task, blocker) {
+		return domain.Forbidden
+	}
+
+	if err := s.repo.AddDependency(ctx, userID, dep); err != nil {
+		logger.Error("Failed to add dependency", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	return nil
+}
+
+func (s *TaskService) RemoveDependency(ctx context.Context, dep domain.TaskDependency) error {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.RemoveDependency(ctx, userID, dep); err != nil {
+		logger.Error("Failed to remove dependency", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	// The task may have been waiting only for this blocker to complete.
+	s.completeAncestors(ctx, dep.TaskID)
+
+	return nil
+}
+
+// GetCriticalPath returns the longest chain of open blockers that ends at
+// the task.
+func (s *TaskService) GetCriticalPath(ctx context.Context, taskID int) ([]*domain.Task, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetTask(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	tasks, deps, err := s.repo.GetDependencyGraph(ctx, taskID)
+	if err != nil {
+		logger.Error("Failed to get dependency graph", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return domain.CriticalPath(taskID, tasks, deps), nil
+}
+
+// checkBlockers refuses to start or finish a task, moving it to a status
+// outside the todo category, while any of its blockers is open. Updates that
+// keep the status are let through.
+func (s *TaskService) checkBlockers(ctx context.Context, task, current *domain.Task) error {
+	if task.StatusCategory == domain.StatusCategoryTodo || current.Status == task.Status {
+		return nil
+	}
+
+	open, err := s.repo.CountOpenBlockers(ctx, task.ID)
+	if err != nil {
+		logger.Error("Failed to count open blockers", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+	if open > 0 {
+		return domain.TaskBlocked
+	}
+
+	return nil
+}
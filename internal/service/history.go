@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// historyAccess checks that the caller may read the history of the task. A
+// deleted task is authorized by its owner and project as of its last event.
+func (s *TaskService) historyAccess(ctx context.Context, taskID int) error {
+	_, err := taskAccess(ctx, s.repo, s.projects, taskID, domain.ProjectRoleViewer)
+	if !errors.Is(err, domain.TaskNotFound) {
+		return err
+	}
+
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	scope, err := s.repo.GetTaskEventScope(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	if scope.ProjectID == 0 {
+		if scope.UserID != userID {
+			return domain.TaskNotFound
+		}
+		return nil
+	}
+
+	err = requireProjectRole(ctx, s.projects, scope.ProjectID, userID, domain.ProjectRoleViewer)
+	if errors.Is(err, domain.ProjectNotFound) {
+		return domain.TaskNotFound
+	}
+	return err
+}
+
+// GetTaskHistory returns a page of the change events of the task, oldest
+// first, and the number of events.
+func (s *TaskService) GetTaskHistory(ctx context.Context, taskID int, page domain.Page) ([]*domain.TaskEvent, int, error) {
+	if !domain.ValidPage(&page) {
+		return nil, 0, domain.InvalidPage
+	}
+
+	if err := s.historyAccess(ctx, taskID); err != nil {
+		return nil, 0, err
+	}
+
+	events, total, err := s.repo.GetTaskHistory(ctx, taskID, page)
+	if err != nil {
+		logger.Error("Failed to get task history", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
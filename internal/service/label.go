@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/repository"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type LabelService struct {
+	repo     repository.LabelRepositoryInterface
+	projects repository.ProjectRepositoryInterface
+}
+
+func NewLabelService(repo repository.LabelRepositoryInterface, projects repository.ProjectRepositoryInterface) LabelServiceInterface {
+	return &LabelService{repo: repo, projects: projects}
+}
+
+// normalizeTaskLabels validates the labels of the task and drops repeated names.
+func normalizeTaskLabels(task *domain.Task) error {
+	labels := make([]*domain.Label, 0, len(task.Labels))
+	seen := make(map[string]bool)
+	for _, label := range task.Labels {
+		if !domain.NormalizeLabel(label) {
+			return domain.InvalidLabel
+		}
+		if seen[label.Name] {
+			continue
+		}
+		seen[label.Name] = true
+		labels = append(labels, label)
+	}
+
+	task.Labels = labels
+	return nil
+}
+
+// label loads a label of the caller, or of a project in which the caller
+// holds at least the required role.
+func (s *LabelService) label(ctx context.Context, labelID int, required string) (*domain.Label, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	label, err := s.repo.GetLabel(ctx, labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if label.ProjectID == 0 {
+		if label.UserID != userID {
+			return nil, domain.LabelNotFound
+		}
+		return label, nil
+	}
+
+	if err := requireProjectRole(ctx, s.projects, label.ProjectID, userID, required); err != nil {
+		if errors.Is(err, domain.ProjectNotFound) {
+			return nil, domain.LabelNotFound
+		}
+		return nil, err
+	}
+
+	return label, nil
+}
+
+func (s *LabelService) GetLabels(ctx context.Context, projectID int) ([]*domain.Label, error) {
+	scope, err := projectScope(ctx, s.projects, projectID, domain.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := s.repo.GetLabels(ctx, scope)
+	if err != nil {
+		logger.Error("Failed to get labels", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+func (s *LabelService) CreateLabel(ctx context.Context, label *domain.Label) (*domain.Label, error) {
+	if !domain.NormalizeLabel(label) {
+		return nil, domain.InvalidLabel
+	}
+
+	scope, err := projectScope(ctx, s.projects, label.ProjectID, domain.ProjectRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	label.UserID = scope.UserID
+
+	created, err := s.repo.CreateLabel(ctx, label)
+	if err != nil {
+		if !errors.Is(err, domain.LabelAlreadyExists) {
+			logger.Error("Failed to create label", zap.Error(err), zap.String("module", "skillsrock"))
+		}
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *LabelService) UpdateLabel(ctx context.Context, label *domain.Label) (*domain.Label, error) {
+	if !domain.NormalizeLabel(label) {
+		return nil, domain.InvalidLabel
+	}
+
+	if _, err := s.label(ctx, label.ID, domain.ProjectRoleEditor); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.UpdateLabel(ctx, label)
+	if err != nil {
+		if !errors.Is(err, domain.LabelAlreadyExists) {
+			logger.Error("Failed to update label", zap.Error(err), zap.String("module", "skillsrock"))
+		}
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *LabelService) DeleteLabel(ctx context.Context, labelID int) error {
+	if _, err := s.label(ctx, labelID, domain.ProjectRoleEditor); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteLabel(ctx, labelID); err != nil {
+		logger.Error("Failed to delete label", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	return nil
+}
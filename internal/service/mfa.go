@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/repository"
+	"github.com/wazwki/skillsrock/pkg/hashutil"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"github.com/wazwki/skillsrock/pkg/totp"
+	"go.uber.org/zap"
+)
+
+const (
+	totpIssuer         = "skillsrock"
+	recoveryCodesCount = 10
+)
+
+type MFAService struct {
+	users  repository.UserRepositoryInterface
+	repo   repository.MFARepositoryInterface
+	tokens repository.TokenRepositoryInterface
+	hasher *hashutil.Hasher
+}
+
+func NewMFAService(users repository.UserRepositoryInterface, repo repository.MFARepositoryInterface, tokens repository.TokenRepositoryInterface, hasher *hashutil.Hasher) MFAServiceInterface {
+	return &MFAService{users: users, repo: repo, tokens: tokens, hasher: hasher}
+}
+
+// normalizeRecoveryCode lets users type recovery codes without the dash and
+// in any case.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
+
+// verifySecondFactor accepts a TOTP code of an enabled enrolment or an unused
+// recovery code. Both work only once.
+func verifySecondFactor(ctx context.Context, repo repository.MFARepositoryInterface, userID int, code string) error {
+	secret, enabled, err := repo.GetTOTPSecret(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return domain.MFANotEnrolled
+	}
+
+	if step, ok := totp.Validate(secret, code, time.Now()); ok {
+		fresh, err := repo.UseTOTPStep(ctx, userID, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return domain.InvalidMFACode
+		}
+		return nil
+	}
+
+	return repo.UseRecoveryCode(ctx, userID, hashSecret(normalizeRecoveryCode(code)))
+}
+
+// EnrolTOTP generates a new secret that becomes active once confirmed with a
+// code. Enrolling again before confirmation replaces the secret.
+func (s *MFAService) EnrolTOTP(ctx context.Context) (*domain.TOTPEnrolment, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.GetUser(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get user", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetTOTPSecret(ctx, userID, secret); err != nil {
+		if !errors.Is(err, domain.MFAAlreadyEnabled) {
+			logger.Error("Failed to set totp secret", zap.Error(err), zap.String("module", "skillsrock"))
+		}
+		return nil, err
+	}
+
+	return &domain.TOTPEnrolment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(totpIssuer, user.Name, secret),
+	}, nil
+}
+
+// ConfirmTOTP enables TOTP and returns fresh recovery codes, which are shown
+// only this once.
+func (s *MFAService) ConfirmTOTP(ctx context.Context, code string) ([]string, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return nil, err
+	}
+
+	secret, enabled, err := s.repo.GetTOTPSecret(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, domain.MFAAlreadyEnabled
+	}
+
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		return nil, domain.InvalidMFACode
+	}
+	if _, err := s.repo.UseTOTPStep(ctx, userID, step); err != nil {
+		logger.Error("Failed to record totp step", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	codes := make([]string, 0, recoveryCodesCount)
+	hashes := make([]string, 0, recoveryCodesCount)
+	for i := 0; i < recoveryCodesCount; i++ {
+		code, err := randomHex(5)
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code[:5]+"-"+code[5:])
+		hashes = append(hashes, hashSecret(code))
+	}
+
+	if err := s.repo.EnableTOTP(ctx, userID, hashes); err != nil {
+		logger.Error("Failed to enable totp", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// DisableTOTP requires the password so that an unattended session cannot
+// turn the second factor off.
+func (s *MFAService) DisableTOTP(ctx context.Context, password string) error {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return err
+	}
+
+	user, err := s.users.GetUser(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get user", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	if user.Password == "" {
+		if err := requireRecentLogin(ctx, s.tokens, userID); err != nil {
+			return err
+		}
+	} else if !s.hasher.Compare(user.Password, password) {
+		return domain.InvalidPassword
+	}
+
+	if err := s.repo.DisableTOTP(ctx, userID); err != nil {
+		logger.Error("Failed to disable totp", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/repository"
+	"github.com/wazwki/skillsrock/internal/service"
+)
+
+type fakeMFA struct {
+	repository.MFARepositoryInterface
+	disabled []int
+}
+
+func (f *fakeMFA) DisableTOTP(ctx context.Context, userID int) error {
+	f.disabled = append(f.disabled, userID)
+	return nil
+}
+
+func TestDisableTOTPWithoutPassword(t *testing.T) {
+	ctx, users, tokens := oidcAccount(time.Now().Add(-time.Minute))
+	mfa := &fakeMFA{}
+	s := service.NewMFAService(users, mfa, tokens, nil)
+
+	assert.NoError(t, s.DisableTOTP(ctx, ""))
+	assert.Equal(t, []int{1}, mfa.disabled)
+}
+
+func TestDisableTOTPWithoutPasswordStaleLogin(t *testing.T) {
+	ctx, users, tokens := oidcAccount(time.Now().Add(-time.Hour))
+	mfa := &fakeMFA{}
+	s := service.NewMFAService(users, mfa, tokens, nil)
+
+	assert.ErrorIs(t, s.DisableTOTP(ctx, ""), domain.RecentLoginRequired)
+	assert.Empty(t, mfa.disabled)
+}
@@ -0,0 +1,148 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	domain "github.com/wazwki/skillsrock/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AttachmentServiceInterface is an autogenerated mock type for the AttachmentServiceInterface type
+type AttachmentServiceInterface struct {
+	mock.Mock
+}
+
+// DeleteAttachment provides a mock function with given fields: ctx, taskID, attachmentID
+func (_m *AttachmentServiceInterface) DeleteAttachment(ctx context.Context, taskID int, attachmentID int) error {
+	ret := _m.Called(ctx, taskID, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAttachment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, taskID, attachmentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAttachments provides a mock function with given fields: ctx, taskID
+func (_m *AttachmentServiceInterface) GetAttachments(ctx context.Context, taskID int) ([]*domain.Attachment, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttachments")
+	}
+
+	var r0 []*domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.Attachment, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.Attachment); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OpenAttachment provides a mock function with given fields: ctx, taskID, attachmentID
+func (_m *AttachmentServiceInterface) OpenAttachment(ctx context.Context, taskID int, attachmentID int) (*domain.Attachment, io.ReadCloser, error) {
+	ret := _m.Called(ctx, taskID, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenAttachment")
+	}
+
+	var r0 *domain.Attachment
+	var r1 io.ReadCloser
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*domain.Attachment, io.ReadCloser, error)); ok {
+		return rf(ctx, taskID, attachmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *domain.Attachment); ok {
+		r0 = rf(ctx, taskID, attachmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) io.ReadCloser); ok {
+		r1 = rf(ctx, taskID, attachmentID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int) error); ok {
+		r2 = rf(ctx, taskID, attachmentID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UploadAttachment provides a mock function with given fields: ctx, attachment, r
+func (_m *AttachmentServiceInterface) UploadAttachment(ctx context.Context, attachment *domain.Attachment, r io.Reader) (*domain.Attachment, error) {
+	ret := _m.Called(ctx, attachment, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadAttachment")
+	}
+
+	var r0 *domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Attachment, io.Reader) (*domain.Attachment, error)); ok {
+		return rf(ctx, attachment, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Attachment, io.Reader) *domain.Attachment); ok {
+		r0 = rf(ctx, attachment, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Attachment, io.Reader) error); ok {
+		r1 = rf(ctx, attachment, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAttachmentServiceInterface creates a new instance of AttachmentServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttachmentServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttachmentServiceInterface {
+	mock := &AttachmentServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
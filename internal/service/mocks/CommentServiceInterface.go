@@ -0,0 +1,144 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/wazwki/skillsrock/internal/domain"
+)
+
+// CommentServiceInterface is an autogenerated mock type for the CommentServiceInterface type
+type CommentServiceInterface struct {
+	mock.Mock
+}
+
+// CreateComment provides a mock function with given fields: ctx, comment
+func (_m *CommentServiceInterface) CreateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+	ret := _m.Called(ctx, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateComment")
+	}
+
+	var r0 *domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Comment) (*domain.Comment, error)); ok {
+		return rf(ctx, comment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Comment) *domain.Comment); ok {
+		r0 = rf(ctx, comment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Comment) error); ok {
+		r1 = rf(ctx, comment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteComment provides a mock function with given fields: ctx, taskID, commentID
+func (_m *CommentServiceInterface) DeleteComment(ctx context.Context, taskID int, commentID int) error {
+	ret := _m.Called(ctx, taskID, commentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, taskID, commentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetComments provides a mock function with given fields: ctx, taskID, page
+func (_m *CommentServiceInterface) GetComments(ctx context.Context, taskID int, page domain.Page) ([]*domain.Comment, int, error) {
+	ret := _m.Called(ctx, taskID, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetComments")
+	}
+
+	var r0 []*domain.Comment
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, domain.Page) ([]*domain.Comment, int, error)); ok {
+		return rf(ctx, taskID, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, domain.Page) []*domain.Comment); ok {
+		r0 = rf(ctx, taskID, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, domain.Page) int); ok {
+		r1 = rf(ctx, taskID, page)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, domain.Page) error); ok {
+		r2 = rf(ctx, taskID, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateComment provides a mock function with given fields: ctx, comment
+func (_m *CommentServiceInterface) UpdateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+	ret := _m.Called(ctx, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateComment")
+	}
+
+	var r0 *domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Comment) (*domain.Comment, error)); ok {
+		return rf(ctx, comment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Comment) *domain.Comment); ok {
+		r0 = rf(ctx, comment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Comment) error); ok {
+		r1 = rf(ctx, comment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCommentServiceInterface creates a new instance of CommentServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCommentServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CommentServiceInterface {
+	mock := &CommentServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
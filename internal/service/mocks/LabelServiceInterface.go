@@ -0,0 +1,137 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/wazwki/skillsrock/internal/domain"
+)
+
+// LabelServiceInterface is an autogenerated mock type for the LabelServiceInterface type
+type LabelServiceInterface struct {
+	mock.Mock
+}
+
+// CreateLabel provides a mock function with given fields: ctx, label
+func (_m *LabelServiceInterface) CreateLabel(ctx context.Context, label *domain.Label) (*domain.Label, error) {
+	ret := _m.Called(ctx, label)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLabel")
+	}
+
+	var r0 *domain.Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Label) (*domain.Label, error)); ok {
+		return rf(ctx, label)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Label) *domain.Label); ok {
+		r0 = rf(ctx, label)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Label)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Label) error); ok {
+		r1 = rf(ctx, label)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteLabel provides a mock function with given fields: ctx, labelID
+func (_m *LabelServiceInterface) DeleteLabel(ctx context.Context, labelID int) error {
+	ret := _m.Called(ctx, labelID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLabel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, labelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetLabels provides a mock function with given fields: ctx, projectID
+func (_m *LabelServiceInterface) GetLabels(ctx context.Context, projectID int) ([]*domain.Label, error) {
+	ret := _m.Called(ctx, projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLabels")
+	}
+
+	var r0 []*domain.Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.Label, error)); ok {
+		return rf(ctx, projectID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.Label); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Label)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLabel provides a mock function with given fields: ctx, label
+func (_m *LabelServiceInterface) UpdateLabel(ctx context.Context, label *domain.Label) (*domain.Label, error) {
+	ret := _m.Called(ctx, label)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLabel")
+	}
+
+	var r0 *domain.Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Label) (*domain.Label, error)); ok {
+		return rf(ctx, label)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Label) *domain.Label); ok {
+		r0 = rf(ctx, label)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Label)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Label) error); ok {
+		r1 = rf(ctx, label)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewLabelServiceInterface creates a new instance of LabelServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLabelServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LabelServiceInterface {
+	mock := &LabelServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
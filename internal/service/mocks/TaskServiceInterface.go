@@ -14,6 +14,24 @@ type TaskServiceInterface struct {
 	mock.Mock
 }
 
+// AddDependency provides a mock function with given fields: ctx, dep
+func (_m *TaskServiceInterface) AddDependency(ctx context.Context, dep domain.TaskDependency) error {
+	ret := _m.Called(ctx, dep)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDependency")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TaskDependency) error); ok {
+		r0 = rf(ctx, dep)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateTask provides a mock function with given fields: ctx, task
 func (_m *TaskServiceInterface) CreateTask(ctx context.Context, task *domain.Task) (string, error) {
 	ret := _m.Called(ctx, task)
@@ -42,17 +60,17 @@ func (_m *TaskServiceInterface) CreateTask(ctx context.Context, task *domain.Tas
 	return r0, r1
 }
 
-// DeleteTask provides a mock function with given fields: ctx, task_id
-func (_m *TaskServiceInterface) DeleteTask(ctx context.Context, task_id string) error {
-	ret := _m.Called(ctx, task_id)
+// DeleteTask provides a mock function with given fields: ctx, task_id, children
+func (_m *TaskServiceInterface) DeleteTask(ctx context.Context, task_id string, children string) error {
+	ret := _m.Called(ctx, task_id, children)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteTask")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, task_id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, task_id, children)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -60,9 +78,9 @@ func (_m *TaskServiceInterface) DeleteTask(ctx context.Context, task_id string)
 	return r0
 }
 
-// ExportTasks provides a mock function with given fields: ctx
-func (_m *TaskServiceInterface) ExportTasks(ctx context.Context) ([]*domain.Task, error) {
-	ret := _m.Called(ctx)
+// ExportTasks provides a mock function with given fields: ctx, projectID
+func (_m *TaskServiceInterface) ExportTasks(ctx context.Context, projectID int) ([]*domain.Task, error) {
+	ret := _m.Called(ctx, projectID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ExportTasks")
@@ -70,19 +88,19 @@ func (_m *TaskServiceInterface) ExportTasks(ctx context.Context) ([]*domain.Task
 
 	var r0 []*domain.Task
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.Task, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.Task, error)); ok {
+		return rf(ctx, projectID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*domain.Task); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.Task); ok {
+		r0 = rf(ctx, projectID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Task)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, projectID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -90,9 +108,9 @@ func (_m *TaskServiceInterface) ExportTasks(ctx context.Context) ([]*domain.Task
 	return r0, r1
 }
 
-// GetAnalytics provides a mock function with given fields: ctx
-func (_m *TaskServiceInterface) GetAnalytics(ctx context.Context) (*domain.Analyse, error) {
-	ret := _m.Called(ctx)
+// GetAnalytics provides a mock function with given fields: ctx, projectID
+func (_m *TaskServiceInterface) GetAnalytics(ctx context.Context, projectID int) (*domain.Analyse, error) {
+	ret := _m.Called(ctx, projectID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAnalytics")
@@ -100,19 +118,146 @@ func (_m *TaskServiceInterface) GetAnalytics(ctx context.Context) (*domain.Analy
 
 	var r0 *domain.Analyse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*domain.Analyse, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.Analyse, error)); ok {
+		return rf(ctx, projectID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *domain.Analyse); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.Analyse); ok {
+		r0 = rf(ctx, projectID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Analyse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockers provides a mock function with given fields: ctx, taskID
+func (_m *TaskServiceInterface) GetBlockers(ctx context.Context, taskID int) ([]*domain.Task, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockers")
+	}
+
+	var r0 []*domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.Task, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.Task); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCriticalPath provides a mock function with given fields: ctx, taskID
+func (_m *TaskServiceInterface) GetCriticalPath(ctx context.Context, taskID int) ([]*domain.Task, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCriticalPath")
+	}
+
+	var r0 []*domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.Task, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.Task); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaskHistory provides a mock function with given fields: ctx, taskID, page
+func (_m *TaskServiceInterface) GetTaskHistory(ctx context.Context, taskID int, page domain.Page) ([]*domain.TaskEvent, int, error) {
+	ret := _m.Called(ctx, taskID, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskHistory")
+	}
+
+	var r0 []*domain.TaskEvent
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, domain.Page) ([]*domain.TaskEvent, int, error)); ok {
+		return rf(ctx, taskID, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, domain.Page) []*domain.TaskEvent); ok {
+		r0 = rf(ctx, taskID, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.TaskEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, domain.Page) int); ok {
+		r1 = rf(ctx, taskID, page)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, domain.Page) error); ok {
+		r2 = rf(ctx, taskID, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetTaskTree provides a mock function with given fields: ctx, taskID
+func (_m *TaskServiceInterface) GetTaskTree(ctx context.Context, taskID int) (*domain.TaskNode, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskTree")
+	}
+
+	var r0 *domain.TaskNode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.TaskNode, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.TaskNode); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TaskNode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, taskID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -150,17 +295,47 @@ func (_m *TaskServiceInterface) GetTasks(ctx context.Context, filter domain.Task
 	return r0, r1
 }
 
-// ImportTasks provides a mock function with given fields: ctx, task
-func (_m *TaskServiceInterface) ImportTasks(ctx context.Context, task []*domain.Task) error {
-	ret := _m.Called(ctx, task)
+// GetTrash provides a mock function with given fields: ctx, projectID
+func (_m *TaskServiceInterface) GetTrash(ctx context.Context, projectID int) ([]*domain.Task, error) {
+	ret := _m.Called(ctx, projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrash")
+	}
+
+	var r0 []*domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*domain.Task, error)); ok {
+		return rf(ctx, projectID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*domain.Task); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportTasks provides a mock function with given fields: ctx, projectID, task
+func (_m *TaskServiceInterface) ImportTasks(ctx context.Context, projectID int, task []*domain.Task) error {
+	ret := _m.Called(ctx, projectID, task)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ImportTasks")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Task) error); ok {
-		r0 = rf(ctx, task)
+	if rf, ok := ret.Get(0).(func(context.Context, int, []*domain.Task) error); ok {
+		r0 = rf(ctx, projectID, task)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -168,9 +343,87 @@ func (_m *TaskServiceInterface) ImportTasks(ctx context.Context, task []*domain.
 	return r0
 }
 
-// UpdateTask provides a mock function with given fields: ctx, task
-func (_m *TaskServiceInterface) UpdateTask(ctx context.Context, task *domain.Task) (*domain.Task, error) {
-	ret := _m.Called(ctx, task)
+// RemoveDependency provides a mock function with given fields: ctx, dep
+func (_m *TaskServiceInterface) RemoveDependency(ctx context.Context, dep domain.TaskDependency) error {
+	ret := _m.Called(ctx, dep)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDependency")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.TaskDependency) error); ok {
+		r0 = rf(ctx, dep)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RestoreTask provides a mock function with given fields: ctx, taskID
+func (_m *TaskServiceInterface) RestoreTask(ctx context.Context, taskID int) (*domain.Task, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreTask")
+	}
+
+	var r0 *domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.Task, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.Task); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetParent provides a mock function with given fields: ctx, taskID, parentID
+func (_m *TaskServiceInterface) SetParent(ctx context.Context, taskID int, parentID int) (*domain.Task, error) {
+	ret := _m.Called(ctx, taskID, parentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetParent")
+	}
+
+	var r0 *domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*domain.Task, error)); ok {
+		return rf(ctx, taskID, parentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *domain.Task); ok {
+		r0 = rf(ctx, taskID, parentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, taskID, parentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTask provides a mock function with given fields: ctx, task, occurrences
+func (_m *TaskServiceInterface) UpdateTask(ctx context.Context, task *domain.Task, occurrences string) (*domain.Task, error) {
+	ret := _m.Called(ctx, task, occurrences)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateTask")
@@ -178,19 +431,19 @@ func (_m *TaskServiceInterface) UpdateTask(ctx context.Context, task *domain.Tas
 
 	var r0 *domain.Task
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.Task) (*domain.Task, error)); ok {
-		return rf(ctx, task)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Task, string) (*domain.Task, error)); ok {
+		return rf(ctx, task, occurrences)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.Task) *domain.Task); ok {
-		r0 = rf(ctx, task)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Task, string) *domain.Task); ok {
+		r0 = rf(ctx, task, occurrences)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Task)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *domain.Task) error); ok {
-		r1 = rf(ctx, task)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Task, string) error); ok {
+		r1 = rf(ctx, task, occurrences)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,107 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	domain "github.com/wazwki/skillsrock/internal/domain"
+)
+
+// WorkflowServiceInterface is an autogenerated mock type for the WorkflowServiceInterface type
+type WorkflowServiceInterface struct {
+	mock.Mock
+}
+
+// GetWorkflow provides a mock function with given fields: ctx, projectID
+func (_m *WorkflowServiceInterface) GetWorkflow(ctx context.Context, projectID int) (*domain.Workflow, error) {
+	ret := _m.Called(ctx, projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflow")
+	}
+
+	var r0 *domain.Workflow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*domain.Workflow, error)); ok {
+		return rf(ctx, projectID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *domain.Workflow); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Workflow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetWorkflow provides a mock function with given fields: ctx, projectID
+func (_m *WorkflowServiceInterface) ResetWorkflow(ctx context.Context, projectID int) error {
+	ret := _m.Called(ctx, projectID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetWorkflow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, projectID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetWorkflow provides a mock function with given fields: ctx, workflow
+func (_m *WorkflowServiceInterface) SetWorkflow(ctx context.Context, workflow *domain.Workflow) (*domain.Workflow, error) {
+	ret := _m.Called(ctx, workflow)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWorkflow")
+	}
+
+	var r0 *domain.Workflow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Workflow) (*domain.Workflow, error)); ok {
+		return rf(ctx, workflow)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Workflow) *domain.Workflow); ok {
+		r0 = rf(ctx, workflow)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Workflow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.Workflow) error); ok {
+		r1 = rf(ctx, workflow)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewWorkflowServiceInterface creates a new instance of WorkflowServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWorkflowServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WorkflowServiceInterface {
+	mock := &WorkflowServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
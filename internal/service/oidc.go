@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"github.com/wazwki/skillsrock/pkg/oidc"
+	"go.uber.org/zap"
+)
+
+// oidcLinkTTL bounds how long linking may take at the provider, as long as
+// the browser keeps the request.
+const oidcLinkTTL = 10 * time.Minute
+
+// StartOIDCLogin begins an authorization code flow with PKCE at the
+// configured identity provider.
+func (s *UserService) StartOIDCLogin(ctx context.Context) (*domain.OIDCLogin, error) {
+	if s.oidc == nil {
+		return nil, domain.OIDCDisabled
+	}
+
+	state, err := oidc.RandomString()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := oidc.RandomString()
+	if err != nil {
+		return nil, err
+	}
+	verifier, challenge, err := oidc.NewPKCE()
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := s.oidc.AuthCodeURL(ctx, state, nonce, challenge)
+	if err != nil {
+		logger.Error("Failed to build OIDC authorization request", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return &domain.OIDCLogin{URL: url, State: state, Nonce: nonce, Verifier: verifier}, nil
+}
+
+// CompleteOIDCLogin redeems the code the provider redirected back with and
+// starts a session for the user of the ID token, or returns an MFA challenge
+// if the user has two-factor authentication enabled. Users are found by their
+// linked identity and otherwise created. Existing accounts are never linked
+// by email, which their owners set without proof: they are linked from a
+// signed-in session with StartOIDCLink.
+func (s *UserService) CompleteOIDCLogin(ctx context.Context, login *domain.OIDCLogin, code, state string) (*domain.Tokens, error) {
+	if s.oidc == nil {
+		return nil, domain.OIDCDisabled
+	}
+
+	if login.State == "" || subtle.ConstantTimeCompare([]byte(login.State), []byte(state)) != 1 {
+		return nil, domain.InvalidToken
+	}
+
+	rawToken, err := s.oidc.Exchange(ctx, code, login.Verifier)
+	if err != nil {
+		logger.Warn("Failed to exchange OIDC code", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, domain.InvalidToken
+	}
+
+	claims, err := s.oidc.VerifyIDToken(ctx, rawToken, login.Nonce)
+	if err != nil {
+		logger.Warn("Rejected OIDC ID token", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, domain.InvalidToken
+	}
+
+	var user *domain.User
+	if login.LinkToken != "" {
+		user, err = s.linkIdentity(ctx, login, claims)
+	} else {
+		user, err = s.oidcUser(ctx, claims)
+	}
+	if err != nil {
+		if errors.Is(err, domain.InvalidToken) || errors.Is(err, domain.IdentityInUse) {
+			return nil, err
+		}
+		logger.Error("Failed to get OIDC user", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+	if user.Disabled {
+		return nil, domain.UserDisabled
+	}
+	if user.MFAEnabled {
+		return s.mfaChallenge(ctx, user)
+	}
+
+	return s.startSession(ctx, user)
+}
+
+func (s *UserService) oidcUser(ctx context.Context, claims *oidc.Claims) (*domain.User, error) {
+	identity := &domain.Identity{Issuer: s.oidc.Issuer(), Subject: claims.Subject, Email: claims.Email}
+
+	user, err := s.identities.GetIdentityUser(ctx, identity.Issuer, identity.Subject)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, domain.IdentityNotFound) {
+		return nil, err
+	}
+
+	base := oidcUserName(claims)
+	user = &domain.User{Name: base, DisplayName: claims.Name}
+	if claims.EmailVerified {
+		user.Email = claims.Email
+	}
+
+	// Names are unique, so a taken one gets a random suffix.
+	for range 5 {
+		created, err := s.identities.CreateUserWithIdentity(ctx, user, identity)
+		if !errors.Is(err, domain.UserAlreadyExists) {
+			return created, err
+		}
+
+		suffix, err := randomHex(3)
+		if err != nil {
+			return nil, err
+		}
+		user.Name = base + "-" + suffix
+	}
+
+	return nil, domain.UserAlreadyExists
+}
+
+// StartOIDCLink begins an authorization request that links the account at
+// the identity provider to the current user. Its callback signs the user in
+// like a login through the provider.
+func (s *UserService) StartOIDCLink(ctx context.Context) (*domain.OIDCLogin, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return nil, err
+	}
+
+	login, err := s.StartOIDCLogin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	login.LinkToken, err = s.jwt.GenerateLinkToken(ctx, userID, login.State, oidcLinkTTL)
+	if err != nil {
+		logger.Error("Failed to generate link token", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return login, nil
+}
+
+// linkIdentity links the identity of the ID token to the user who started
+// the request, unless another user has it already.
+func (s *UserService) linkIdentity(ctx context.Context, login *domain.OIDCLogin, claims *oidc.Claims) (*domain.User, error) {
+	linkClaims, err := s.jwt.ValidateLinkToken(ctx, login.LinkToken)
+	if err != nil || linkClaims.ID != login.State {
+		return nil, domain.InvalidToken
+	}
+
+	identity := &domain.Identity{UserID: linkClaims.UserID, Issuer: s.oidc.Issuer(), Subject: claims.Subject, Email: claims.Email}
+	if err := s.identities.LinkIdentity(ctx, identity); err != nil {
+		return nil, err
+	}
+
+	// Linking keeps an existing link, so the user is read back through it.
+	user, err := s.identities.GetIdentityUser(ctx, identity.Issuer, identity.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if user.ID != identity.UserID {
+		return nil, domain.IdentityInUse
+	}
+
+	return user, nil
+}
+
+func oidcUserName(claims *oidc.Claims) string {
+	if claims.PreferredUsername != "" {
+		return claims.PreferredUsername
+	}
+	if name, _, ok := strings.Cut(claims.Email, "@"); ok && name != "" {
+		return name
+	}
+	return "user"
+}
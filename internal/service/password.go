@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/repository"
+	"github.com/wazwki/skillsrock/pkg/hashutil"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"github.com/wazwki/skillsrock/pkg/notify"
+	"go.uber.org/zap"
+)
+
+type PasswordService struct {
+	users    repository.UserRepositoryInterface
+	resets   repository.PasswordResetRepositoryInterface
+	tokens   repository.TokenRepositoryInterface
+	hasher   *hashutil.Hasher
+	notifier notify.Notifier
+	resetTTL time.Duration
+}
+
+func NewPasswordService(users repository.UserRepositoryInterface, resets repository.PasswordResetRepositoryInterface, tokens repository.TokenRepositoryInterface, hasher *hashutil.Hasher, notifier notify.Notifier, resetTTL time.Duration) PasswordServiceInterface {
+	return &PasswordService{users: users, resets: resets, tokens: tokens, hasher: hasher, notifier: notifier, resetTTL: resetTTL}
+}
+
+// ChangePassword replaces the password of the current user and ends all of
+// their sessions.
+func (s *PasswordService) ChangePassword(ctx context.Context, currentPassword, newPassword string) error {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return err
+	}
+
+	if !domain.ValidPassword(newPassword) {
+		return domain.WeakPassword
+	}
+
+	user, err := s.users.GetUser(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get user", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	if !s.hasher.Compare(user.Password, currentPassword) {
+		return domain.InvalidPassword
+	}
+
+	return s.setPassword(ctx, userID, newPassword)
+}
+
+// RequestPasswordReset sends a single-use reset token to the user. Unknown
+// and disabled users are silently ignored so that accounts cannot be probed.
+func (s *PasswordService) RequestPasswordReset(ctx context.Context, name string) error {
+	user, err := s.users.CheckUser(ctx, &domain.User{Name: name})
+	if err != nil {
+		if errors.Is(err, domain.UserNotFound) {
+			return nil
+		}
+		logger.Error("Failed to get user", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+	if user.Disabled {
+		return nil
+	}
+
+	token, err := randomHex(32)
+	if err != nil {
+		return err
+	}
+
+	if err := s.resets.CreatePasswordReset(ctx, user.ID, hashSecret(token), s.resetTTL); err != nil {
+		logger.Error("Failed to create password reset", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	err = s.notifier.Notify(ctx, notify.Message{
+		To:      user.Name,
+		Subject: "Password reset",
+		Body:    fmt.Sprintf("Use this token to reset your password: %s\nIt expires in %s.", token, s.resetTTL),
+	})
+	if err != nil {
+		logger.Error("Failed to send password reset", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password with a reset token and ends all sessions
+// of the user.
+func (s *PasswordService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if !domain.ValidPassword(newPassword) {
+		return domain.WeakPassword
+	}
+
+	userID, err := s.resets.ConsumePasswordReset(ctx, hashSecret(token))
+	if err != nil {
+		if !errors.Is(err, domain.InvalidToken) {
+			logger.Error("Failed to consume password reset", zap.Error(err), zap.String("module", "skillsrock"))
+		}
+		return err
+	}
+
+	return s.setPassword(ctx, userID, newPassword)
+}
+
+func (s *PasswordService) setPassword(ctx context.Context, userID int, password string) error {
+	hashed, err := s.hasher.Hash(password)
+	if err != nil {
+		logger.Error("Failed to hash password", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	if err := s.users.UpdatePassword(ctx, userID, hashed); err != nil {
+		logger.Error("Failed to update password", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	if err := s.tokens.DeleteUserRefreshFamilies(ctx, userID); err != nil {
+		logger.Error("Failed to delete refresh token families", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/repository"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// recentLoginWindow is how long after signing in a user without a password,
+// such as one provisioned through OIDC, may confirm sensitive changes.
+const recentLoginWindow = 5 * time.Minute
+
+func (s *UserService) GetProfile(ctx context.Context) (*domain.User, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get user", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+	user.Password = ""
+
+	return user, nil
+}
+
+func (s *UserService) UpdateProfile(ctx context.Context, profile *domain.User) (*domain.User, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return nil, err
+	}
+
+	if !domain.ValidProfile(profile) {
+		return nil, domain.InvalidProfile
+	}
+	profile.ID = userID
+
+	updated, err := s.repo.UpdateProfile(ctx, profile)
+	if err != nil {
+		logger.Error("Failed to update profile", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+	updated.Password = ""
+
+	return updated, nil
+}
+
+// DeleteAccount removes the current user after confirming the password, or a
+// recent login for accounts without one, and ends all of their sessions. Personal tasks and API keys go with the
+// account, tasks in shared projects stay with the project owner.
+func (s *UserService) DeleteAccount(ctx context.Context, password string) error {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return err
+	}
+
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get user", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	if user.Password == "" {
+		if err := requireRecentLogin(ctx, s.tokens, userID); err != nil {
+			return err
+		}
+	} else if !s.hasher.Compare(user.Password, password) {
+		return domain.InvalidPassword
+	}
+
+	if err := s.repo.DeleteUser(ctx, userID); err != nil {
+		logger.Error("Failed to delete user", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	if err := s.tokens.DeleteUserRefreshFamilies(ctx, userID); err != nil {
+		logger.Error("Failed to delete refresh token families", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	return nil
+}
+
+// requireRecentLogin checks that the session of the request was started by a
+// login within recentLoginWindow. Refreshes keep the session, so only a new
+// login counts. Accounts without a password confirm with it instead.
+func requireRecentLogin(ctx context.Context, tokens repository.TokenRepositoryInterface, userID int) error {
+	sessionID := domain.SessionIDFromContext(ctx)
+	if sessionID == "" {
+		return domain.RecentLoginRequired
+	}
+
+	session, err := tokens.GetSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, domain.SessionNotFound) {
+			return domain.RecentLoginRequired
+		}
+		logger.Error("Failed to get session", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	if session.UserID != userID || time.Since(session.CreatedAt) > recentLoginWindow {
+		return domain.RecentLoginRequired
+	}
+
+	return nil
+}
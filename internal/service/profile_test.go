@@ -0,0 +1,45 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/service"
+)
+
+// oidcAccount is the context of a request by user 1, who has no password, in
+// a session started at createdAt.
+func oidcAccount(createdAt time.Time) (context.Context, *fakeUsers, *fakeTokens) {
+	users := &fakeUsers{users: map[int]*domain.User{1: {ID: 1, Name: "sso-user", Role: "user"}}}
+	tokens := &fakeTokens{sessions: map[string]*domain.Session{
+		"family": {ID: "family", UserID: 1, CreatedAt: createdAt, LastSeenAt: time.Now()},
+	}}
+
+	ctx := domain.ContextWithUserID(context.Background(), 1)
+	return domain.ContextWithSessionID(ctx, "family"), users, tokens
+}
+
+func TestDeleteAccountWithoutPassword(t *testing.T) {
+	ctx, users, tokens := oidcAccount(time.Now().Add(-time.Minute))
+	s := service.NewUserService(users, tokens, nil, nil, nil, nil, nil, nil, service.LockoutConfig{})
+
+	assert.NoError(t, s.DeleteAccount(ctx, ""))
+	assert.Equal(t, []int{1}, users.deleted)
+}
+
+func TestDeleteAccountWithoutPasswordStaleLogin(t *testing.T) {
+	ctx, users, tokens := oidcAccount(time.Now().Add(-time.Hour))
+	s := service.NewUserService(users, tokens, nil, nil, nil, nil, nil, nil, service.LockoutConfig{})
+
+	assert.ErrorIs(t, s.DeleteAccount(ctx, "anything"), domain.RecentLoginRequired)
+	assert.Empty(t, users.deleted)
+
+	// Sessions of other users do not count.
+	tokens.sessions["family"].CreatedAt = time.Now()
+	tokens.sessions["family"].UserID = 2
+	assert.ErrorIs(t, s.DeleteAccount(ctx, ""), domain.RecentLoginRequired)
+	assert.Empty(t, users.deleted)
+}
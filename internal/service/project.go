@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/repository"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type ProjectService struct {
+	repo repository.ProjectRepositoryInterface
+}
+
+func NewProjectService(repo repository.ProjectRepositoryInterface) ProjectServiceInterface {
+	return &ProjectService{repo: repo}
+}
+
+// requireProjectRole returns domain.ProjectNotFound to non-members, so that
+// project existence is not disclosed, and domain.Forbidden to members whose
+// role is below required.
+func requireProjectRole(ctx context.Context, repo repository.ProjectRepositoryInterface, projectID, userID int, required string) error {
+	role, err := repo.GetMemberRole(ctx, projectID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !domain.ProjectRoleAtLeast(role, required) {
+		return domain.Forbidden
+	}
+
+	return nil
+}
+
+// projectScope resolves the caller and, for project-scoped calls, checks that
+// the caller holds at least the required role in that project.
+func projectScope(ctx context.Context, repo repository.ProjectRepositoryInterface, projectID int, required string) (domain.TaskScope, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return domain.TaskScope{}, err
+	}
+
+	if projectID != 0 {
+		if err := requireProjectRole(ctx, repo, projectID, userID, required); err != nil {
+			return domain.TaskScope{}, err
+		}
+	}
+
+	return domain.TaskScope{UserID: userID, ProjectID: projectID}, nil
+}
+
+func (s *ProjectService) CreateProject(ctx context.Context, project *domain.Project) (*domain.Project, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	project.OwnerID = userID
+
+	created, err := s.repo.CreateProject(ctx, project)
+	if err != nil {
+		logger.Error("Failed to create project", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *ProjectService) GetProjects(ctx context.Context) ([]*domain.Project, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := s.repo.GetProjects(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get projects", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+func (s *ProjectService) GetProject(ctx context.Context, projectID int) (*domain.Project, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireProjectRole(ctx, s.repo, projectID, userID, domain.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	project, err := s.repo.GetProject(ctx, projectID)
+	if err != nil {
+		logger.Error("Failed to get project", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (s *ProjectService) UpdateProject(ctx context.Context, project *domain.Project) (*domain.Project, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireProjectRole(ctx, s.repo, project.ID, userID, domain.ProjectRoleOwner); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.UpdateProject(ctx, project)
+	if err != nil {
+		logger.Error("Failed to update project", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *ProjectService) DeleteProject(ctx context.Context, projectID int) error {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := requireProjectRole(ctx, s.repo, projectID, userID, domain.ProjectRoleOwner); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteProject(ctx, projectID); err != nil {
+		logger.Error("Failed to delete project", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	return nil
+}
+
+func (s *ProjectService) GetMembers(ctx context.Context, projectID int) ([]*domain.ProjectMember, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireProjectRole(ctx, s.repo, projectID, userID, domain.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.GetMembers(ctx, projectID)
+	if err != nil {
+		logger.Error("Failed to get project members", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (s *ProjectService) SetMember(ctx context.Context, member *domain.ProjectMember) error {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !domain.ValidProjectRole(member.Role) {
+		return domain.InvalidProjectRole
+	}
+
+	if err := requireProjectRole(ctx, s.repo, member.ProjectID, userID, domain.ProjectRoleOwner); err != nil {
+		return err
+	}
+
+	project, err := s.repo.GetProject(ctx, member.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	if project.OwnerID == member.UserID && member.Role != domain.ProjectRoleOwner {
+		return domain.Forbidden
+	}
+
+	if err := s.repo.SetMember(ctx, member); err != nil {
+		logger.Error("Failed to set project member", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	return nil
+}
+
+func (s *ProjectService) RemoveMember(ctx context.Context, projectID, memberID int) error {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Members may always leave a project on their own.
+	required := domain.ProjectRoleOwner
+	if memberID == userID {
+		required = domain.ProjectRoleViewer
+	}
+
+	if err := requireProjectRole(ctx, s.repo, projectID, userID, required); err != nil {
+		return err
+	}
+
+	project, err := s.repo.GetProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	if project.OwnerID == memberID {
+		return domain.Forbidden
+	}
+
+	if err := s.repo.RemoveMember(ctx, projectID, memberID); err != nil {
+		logger.Error("Failed to remove project member", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"github.com/wazwki/skillsrock/pkg/rrule"
+	"go.uber.org/zap"
+)
+
+func nextOccurrence(rule *rrule.Rule, start, after time.Time) *time.Time {
+	next, ok := rule.After(start, after)
+	if !ok {
+		return nil
+	}
+	return &next
+}
+
+// planSeries validates the recurrence of the task, stores it in canonical
+// form and works out the next occurrence of the series. current is the stored
+// task on updates and nil on creation. The recurrence of an occurrence is
+// only read when all future occurrences change.
+func (s *TaskService) planSeries(ctx context.Context, task, current *domain.Task, future bool) error {
+	if task.Recurrence == "" {
+		return nil
+	}
+	if current != nil && current.SeriesID != 0 && !future {
+		return nil
+	}
+
+	rule, err := rrule.Parse(task.Recurrence)
+	if err != nil {
+		return domain.InvalidRecurrence
+	}
+	task.Recurrence = rule.String()
+
+	start, last := task.Due_date, task.Due_date
+	if current != nil && current.SeriesID != 0 {
+		series, err := s.repo.GetSeries(ctx, current.SeriesID)
+		if err != nil {
+			return err
+		}
+
+		// A changed rule counts from this occurrence on.
+		start, last = series.StartsAt, series.LastAt
+		if task.Recurrence != series.Rule {
+			start = current.OccursAt
+		}
+	}
+	if start.IsZero() {
+		return domain.InvalidRecurrence
+	}
+
+	task.NextOccurrence = nextOccurrence(rule, start, last)
+	return nil
+}
+
+// createOccurrence creates the next occurrence of the series. With catchUp
+// slots that have passed by now are skipped up to the latest one.
+func (s *TaskService) createOccurrence(ctx context.Context, series *domain.TaskSeries, now time.Time, catchUp bool) error {
+	rule, err := rrule.Parse(series.Rule)
+	if err != nil {
+		return err
+	}
+
+	slot := *series.NextAt
+	next := nextOccurrence(rule, series.StartsAt, slot)
+	for catchUp && next != nil && !next.After(now) {
+		slot = *next
+		next = nextOccurrence(rule, series.StartsAt, slot)
+	}
+
+	_, err = s.repo.CreateOccurrence(ctx, series, slot, next)
+	return err
+}
+
+// advanceSeries creates the next occurrence ahead of its slot once the
+// latest occurrence of a series is done.
+func (s *TaskService) advanceSeries(ctx context.Context, task *domain.Task) {
+	if task.SeriesID == 0 {
+		return
+	}
+
+	series, err := s.repo.GetSeries(ctx, task.SeriesID)
+	if err != nil {
+		logger.Error("Failed to get task series", zap.Error(err), zap.String("module", "skillsrock"))
+		return
+	}
+	if series.NextAt == nil || !series.LastAt.Equal(task.OccursAt) {
+		return
+	}
+
+	if err := s.createOccurrence(ctx, series, time.Now().UTC(), false); err != nil {
+		logger.Error("Failed to create task occurrence", zap.Error(err), zap.String("module", "skillsrock"))
+	}
+}
+
+// recurrenceWorker creates the occurrences whose slot has arrived.
+func (s *TaskService) recurrenceWorker(updateInterval time.Duration) {
+	tick := time.NewTicker(updateInterval)
+	defer tick.Stop()
+	for range tick.C {
+		now := time.Now().UTC()
+		series, err := s.repo.GetDueSeries(context.Background(), now)
+		if err != nil {
+			logger.Error("Failed to get due task series", zap.Error(err), zap.String("module", "skillsrock"))
+			continue
+		}
+
+		for _, sr := range series {
+			if err := s.createOccurrence(context.Background(), sr, now, true); err != nil {
+				logger.Error("Failed to create task occurrence", zap.Error(err), zap.String("module", "skillsrock"))
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"github.com/wazwki/skillsrock/pkg/notify"
+	"go.uber.org/zap"
+)
+
+// reminderBatch is how many reminders are claimed at once.
+const reminderBatch = 20
+
+// normalizeTaskReminders validates the reminders of the task, see
+// domain.NormalizeReminders.
+func normalizeTaskReminders(task *domain.Task) error {
+	reminders, ok := domain.NormalizeReminders(task.Reminders)
+	if !ok {
+		return domain.InvalidReminder
+	}
+	task.Reminders = reminders
+	return nil
+}
+
+func reminderMessage(reminder *domain.Reminder) notify.Message {
+	return notify.Message{
+		To:      reminder.Recipient,
+		Email:   reminder.Email,
+		Subject: "Reminder: " + reminder.Title,
+		Body:    fmt.Sprintf("The task %q is due at %s.", reminder.Title, reminder.DueDate.Format("2006-01-02 15:04:05")),
+	}
+}
+
+// sendReminders sends the reminders that are due at now, batch by batch
+// until a batch falls short. Failed deliveries keep their claim, so they are
+// retried on a later run rather than right away.
+func (s *TaskService) sendReminders(ctx context.Context, now time.Time) {
+	for {
+		reminders, err := s.repo.ClaimReminders(ctx, now, reminderBatch)
+		if err != nil {
+			logger.Error("Failed to claim task reminders", zap.Error(err), zap.String("module", "skillsrock"))
+			return
+		}
+
+		for _, reminder := range reminders {
+			err := s.notifier.Notify(ctx, reminderMessage(reminder))
+			if errors.Is(err, notify.ErrNoAddress) {
+				// Owners without an email cannot be mailed, the reminder is
+				// skipped for this due date.
+				err = nil
+			} else if err != nil {
+				logger.Error("Failed to send task reminder", zap.Error(err), zap.Int("task_id", reminder.TaskID), zap.String("module", "skillsrock"))
+			}
+
+			if err := s.repo.RecordReminder(ctx, reminder, err == nil); err != nil {
+				logger.Error("Failed to record task reminder", zap.Error(err), zap.Int("task_id", reminder.TaskID), zap.String("module", "skillsrock"))
+			}
+		}
+
+		if len(reminders) < reminderBatch {
+			return
+		}
+	}
+}
+
+// reminderWorker sends the reminders that have come due.
+func (s *TaskService) reminderWorker(updateInterval time.Duration) {
+	tick := time.NewTicker(updateInterval)
+	defer tick.Stop()
+	for range tick.C {
+		s.sendReminders(context.Background(), time.Now().UTC())
+	}
+}
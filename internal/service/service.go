@@ -2,21 +2,111 @@ package service
 
 import (
 	"context"
+	"io"
 
 	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/pkg/jwtutil"
 )
 
 type TaskServiceInterface interface {
 	CreateTask(ctx context.Context, task *domain.Task) (string, error)
 	GetTasks(ctx context.Context, filter domain.TaskFilter) ([]*domain.Task, error)
-	UpdateTask(ctx context.Context, task *domain.Task) (*domain.Task, error)
-	DeleteTask(ctx context.Context, task_id string) error
-	GetAnalytics(ctx context.Context) (*domain.Analyse, error)
-	ImportTasks(ctx context.Context, task []*domain.Task) error
-	ExportTasks(ctx context.Context) ([]*domain.Task, error)
+	UpdateTask(ctx context.Context, task *domain.Task, occurrences string) (*domain.Task, error)
+	DeleteTask(ctx context.Context, task_id string, children string) error
+	GetTaskTree(ctx context.Context, taskID int) (*domain.TaskNode, error)
+	SetParent(ctx context.Context, taskID, parentID int) (*domain.Task, error)
+	GetBlockers(ctx context.Context, taskID int) ([]*domain.Task, error)
+	AddDependency(ctx context.Context, dep domain.TaskDependency) error
+	RemoveDependency(ctx context.Context, dep domain.TaskDependency) error
+	GetCriticalPath(ctx context.Context, taskID int) ([]*domain.Task, error)
+	GetAnalytics(ctx context.Context, projectID int) (*domain.Analyse, error)
+	ImportTasks(ctx context.Context, projectID int, task []*domain.Task) error
+	ExportTasks(ctx context.Context, projectID int) ([]*domain.Task, error)
+	GetTaskHistory(ctx context.Context, taskID int, page domain.Page) ([]*domain.TaskEvent, int, error)
+	GetTrash(ctx context.Context, projectID int) ([]*domain.Task, error)
+	RestoreTask(ctx context.Context, taskID int) (*domain.Task, error)
+}
+
+type LabelServiceInterface interface {
+	GetLabels(ctx context.Context, projectID int) ([]*domain.Label, error)
+	CreateLabel(ctx context.Context, label *domain.Label) (*domain.Label, error)
+	UpdateLabel(ctx context.Context, label *domain.Label) (*domain.Label, error)
+	DeleteLabel(ctx context.Context, labelID int) error
+}
+
+type WorkflowServiceInterface interface {
+	GetWorkflow(ctx context.Context, projectID int) (*domain.Workflow, error)
+	SetWorkflow(ctx context.Context, workflow *domain.Workflow) (*domain.Workflow, error)
+	ResetWorkflow(ctx context.Context, projectID int) error
+}
+
+type CommentServiceInterface interface {
+	GetComments(ctx context.Context, taskID int, page domain.Page) ([]*domain.Comment, int, error)
+	CreateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error)
+	UpdateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error)
+	DeleteComment(ctx context.Context, taskID, commentID int) error
+}
+
+type AttachmentServiceInterface interface {
+	GetAttachments(ctx context.Context, taskID int) ([]*domain.Attachment, error)
+	UploadAttachment(ctx context.Context, attachment *domain.Attachment, r io.Reader) (*domain.Attachment, error)
+	OpenAttachment(ctx context.Context, taskID, attachmentID int) (*domain.Attachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, taskID, attachmentID int) error
+}
+
+type ProjectServiceInterface interface {
+	CreateProject(ctx context.Context, project *domain.Project) (*domain.Project, error)
+	GetProjects(ctx context.Context) ([]*domain.Project, error)
+	GetProject(ctx context.Context, projectID int) (*domain.Project, error)
+	UpdateProject(ctx context.Context, project *domain.Project) (*domain.Project, error)
+	DeleteProject(ctx context.Context, projectID int) error
+	GetMembers(ctx context.Context, projectID int) ([]*domain.ProjectMember, error)
+	SetMember(ctx context.Context, member *domain.ProjectMember) error
+	RemoveMember(ctx context.Context, projectID, userID int) error
 }
 
 type UserServiceInterface interface {
 	CreateUser(ctx context.Context, user *domain.User) (*domain.User, error)
-	CheckUser(ctx context.Context, user *domain.User) error
+	CheckUser(ctx context.Context, user *domain.User) (*domain.User, error)
+	Login(ctx context.Context, user *domain.User) (*domain.Tokens, error)
+	VerifyMFA(ctx context.Context, mfaToken, code string) (*domain.Tokens, error)
+	StartOIDCLogin(ctx context.Context) (*domain.OIDCLogin, error)
+	CompleteOIDCLogin(ctx context.Context, login *domain.OIDCLogin, code, state string) (*domain.Tokens, error)
+	StartOIDCLink(ctx context.Context) (*domain.OIDCLogin, error)
+	Refresh(ctx context.Context, refreshToken string) (*domain.Tokens, error)
+	Authenticate(ctx context.Context, accessToken string) (*jwtutil.CustomClaims, error)
+	Logout(ctx context.Context, claims *jwtutil.CustomClaims) error
+	LogoutAll(ctx context.Context, claims *jwtutil.CustomClaims) error
+	GetProfile(ctx context.Context) (*domain.User, error)
+	UpdateProfile(ctx context.Context, profile *domain.User) (*domain.User, error)
+	DeleteAccount(ctx context.Context, password string) error
+	GetSessions(ctx context.Context) ([]*domain.Session, error)
+	RevokeSession(ctx context.Context, sessionID string) error
+}
+
+type APIKeyServiceInterface interface {
+	CreateAPIKey(ctx context.Context, key *domain.APIKey) (*domain.APIKey, string, error)
+	GetAPIKeys(ctx context.Context) ([]*domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, keyID int) error
+	Authenticate(ctx context.Context, rawKey string) (*domain.APIKey, error)
+}
+
+type AdminServiceInterface interface {
+	GetUsers(ctx context.Context) ([]*domain.User, error)
+	SetUserRole(ctx context.Context, userID int, role string) error
+	DisableUser(ctx context.Context, userID int) error
+	EnableUser(ctx context.Context, userID int) error
+	ForcePasswordReset(ctx context.Context, userID int) error
+}
+
+type PasswordServiceInterface interface {
+	ChangePassword(ctx context.Context, currentPassword, newPassword string) error
+	RequestPasswordReset(ctx context.Context, name string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+}
+
+type MFAServiceInterface interface {
+	EnrolTOTP(ctx context.Context) (*domain.TOTPEnrolment, error)
+	ConfirmTOTP(ctx context.Context, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, password string) error
 }
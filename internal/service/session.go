@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const maxUserAgentLength = 256
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
+
+// GetSessions lists the sessions of the current user, most recently used
+// first, and marks the one the request was made in.
+func (s *UserService) GetSessions(ctx context.Context) ([]*domain.Session, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.tokens.GetSessions(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get sessions", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	current := domain.SessionIDFromContext(ctx)
+	for _, session := range sessions {
+		session.Current = session.ID == current
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// RevokeSession signs the current user out of one of their sessions. Access
+// tokens issued in it stop working immediately.
+func (s *UserService) RevokeSession(ctx context.Context, sessionID string) error {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := requireSession(ctx); err != nil {
+		return err
+	}
+
+	session, err := s.tokens.GetSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return domain.SessionNotFound
+	}
+
+	if err := s.tokens.DeleteRefreshFamily(ctx, userID, sessionID); err != nil {
+		logger.Error("Failed to delete refresh token family", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	return nil
+}
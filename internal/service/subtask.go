@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// GetTaskTree returns the task with all of its subtasks.
+func (s *TaskService) GetTaskTree(ctx context.Context, taskID int) (*domain.TaskNode, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.repo.GetTask(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	descendants, err := s.repo.GetSubtree(ctx, taskID)
+	if err != nil {
+		logger.Error("Failed to get subtasks", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return domain.BuildTaskTree(task, descendants), nil
+}
+
+// SetParent moves the task under another task of the same project, or to
+// the top level for parentID 0.
+func (s *TaskService) SetParent(ctx context.Context, taskID, parentID int) (*domain.Task, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.repo.GetTask(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.scope(ctx, task.ProjectID, domain.ProjectRoleEditor); err != nil {
+		return nil, err
+	}
+
+	if parentID != 0 {
+		parent, err := s.repo.GetTask(ctx, userID, parentID)
+		if err != nil {
+			return nil, domain.InvalidParentTask
+		}
+		if !sameTaskScope(task, parent) {
+			return nil, domain.InvalidParentTask
+		}
+	}
+
+	moved, err := s.repo.SetParent(ctx, userID, taskID, parentID)
+	if err != nil {
+		logger.Error("Failed to set parent task", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	// The former parent may have lost its last open subtask.
+	s.completeAncestors(ctx, task.ParentID)
+
+	return moved, nil
+}
+
+// sameTaskScope reports whether both tasks are in the same project, or are
+// personal tasks of the same user.
+func sameTaskScope(a, b *domain.Task) bool {
+	return a.ProjectID == b.ProjectID && (a.ProjectID != 0 || a.UserID == b.UserID)
+}
+
+// completeAncestors auto-completes the ancestors starting at taskID. A
+// failure leaves them open and does not fail the triggering change.
+func (s *TaskService) completeAncestors(ctx context.Context, taskID int) {
+	if taskID == 0 {
+		return
+	}
+
+	if err := s.repo.CompleteAncestors(ctx, taskID); err != nil {
+		logger.Error("Failed to complete parent tasks", zap.Error(err), zap.String("module", "skillsrock"))
+	}
+}
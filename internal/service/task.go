@@ -2,28 +2,115 @@ package service
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/wazwki/skillsrock/internal/domain"
 	"github.com/wazwki/skillsrock/internal/repository"
 	"github.com/wazwki/skillsrock/pkg/logger"
+	"github.com/wazwki/skillsrock/pkg/notify"
 	"go.uber.org/zap"
 )
 
 type TaskService struct {
-	repo repository.TaskRepositoryInterface
+	repo      repository.TaskRepositoryInterface
+	projects  repository.ProjectRepositoryInterface
+	workflows repository.WorkflowRepositoryInterface
+	// notifier delivers due date reminders.
+	notifier notify.Notifier
+	// trashRetention is how long deleted tasks stay in the trash.
+	trashRetention time.Duration
 }
 
-func NewTaskService(repo repository.TaskRepositoryInterface) TaskServiceInterface {
-	t := &TaskService{repo: repo}
+func NewTaskService(repo repository.TaskRepositoryInterface, projects repository.ProjectRepositoryInterface, workflows repository.WorkflowRepositoryInterface, notifier notify.Notifier, trashRetention time.Duration) TaskServiceInterface {
+	t := &TaskService{repo: repo, projects: projects, workflows: workflows, notifier: notifier, trashRetention: trashRetention}
 
 	go t.analyseWorker(time.Hour*6, 3, time.Second*5)
 	go t.updateWorker(time.Hour*24, 3, time.Second*5)
+	go t.purgeWorker(time.Hour)
+	go t.recurrenceWorker(time.Minute)
+	go t.reminderWorker(time.Minute)
 
 	return t
 }
 
+// scope resolves the caller, see projectScope.
+func (s *TaskService) scope(ctx context.Context, projectID int, required string) (domain.TaskScope, error) {
+	return projectScope(ctx, s.projects, projectID, required)
+}
+
+func (s *TaskService) workflow(ctx context.Context, projectID int) (*domain.Workflow, error) {
+	workflow, err := s.workflows.GetWorkflow(ctx, projectID)
+	if err != nil {
+		logger.Error("Failed to get workflow", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+	return workflow, nil
+}
+
+// taskAccess checks that the caller sees the task and, for project tasks,
+// holds at least the required role. It returns the caller.
+func taskAccess(ctx context.Context, tasks repository.TaskRepositoryInterface, projects repository.ProjectRepositoryInterface, taskID int, required string) (int, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	task, err := tasks.GetTask(ctx, userID, taskID)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := projectScope(ctx, projects, task.ProjectID, required); err != nil {
+		return 0, err
+	}
+
+	return userID, nil
+}
+
 func (s *TaskService) CreateTask(ctx context.Context, task *domain.Task) (string, error) {
+	if err := normalizeTaskLabels(task); err != nil {
+		return "", err
+	}
+	if err := normalizeTaskReminders(task); err != nil {
+		return "", err
+	}
+
+	// Subtasks always belong to the project of their parent.
+	if task.ParentID != 0 {
+		userID, err := domain.UserIDFromContext(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		parent, err := s.repo.GetTask(ctx, userID, task.ParentID)
+		if err != nil {
+			if errors.Is(err, domain.TaskNotFound) {
+				return "", domain.InvalidParentTask
+			}
+			return "", err
+		}
+		task.ProjectID = parent.ProjectID
+	}
+
+	scope, err := s.scope(ctx, task.ProjectID, domain.ProjectRoleEditor)
+	if err != nil {
+		return "", err
+	}
+	task.UserID = scope.UserID
+
+	workflow, err := s.workflow(ctx, task.ProjectID)
+	if err != nil {
+		return "", err
+	}
+	if err := checkStatus(workflow, task, nil); err != nil {
+		return "", err
+	}
+
+	if err := s.planSeries(ctx, task, nil, false); err != nil {
+		return "", err
+	}
+
 	id, err := s.repo.CreateTask(ctx, task)
 	if err != nil {
 		logger.Error("Failed to create task", zap.Error(err), zap.String("module", "skillsrock"))
@@ -34,6 +121,22 @@ func (s *TaskService) CreateTask(ctx context.Context, task *domain.Task) (string
 }
 
 func (s *TaskService) GetTasks(ctx context.Context, filter domain.TaskFilter) ([]*domain.Task, error) {
+	if filter.LabelMatch == "" {
+		filter.LabelMatch = domain.LabelMatchAny
+	}
+	if !domain.ValidLabelMatch(filter.LabelMatch) {
+		return nil, domain.InvalidLabelMatch
+	}
+	if filter.Category != "" && !domain.ValidStatusCategory(filter.Category) {
+		return nil, domain.InvalidStatusCategory
+	}
+
+	scope, err := s.scope(ctx, filter.ProjectID, domain.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	filter.UserID = scope.UserID
+
 	tasks, err := s.repo.GetTasks(ctx, filter)
 	if err != nil {
 		logger.Error("Failed to get tasks", zap.Error(err), zap.String("module", "skillsrock"))
@@ -43,28 +146,100 @@ func (s *TaskService) GetTasks(ctx context.Context, filter domain.TaskFilter) ([
 	return tasks, nil
 }
 
-func (s *TaskService) UpdateTask(ctx context.Context, task *domain.Task) (*domain.Task, error) {
-	updatedTask, err := s.repo.UpdateTask(ctx, task)
+// UpdateTask changes the task and, depending on occurrences, the following
+// occurrences of a recurring task.
+func (s *TaskService) UpdateTask(ctx context.Context, task *domain.Task, occurrences string) (*domain.Task, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	task.UserID = userID
+
+	if occurrences == "" {
+		occurrences = domain.OccurrencesThis
+	}
+	if !domain.ValidOccurrenceScope(occurrences) {
+		return nil, domain.InvalidOccurrenceScope
+	}
+	future := occurrences == domain.OccurrencesFuture
+
+	if err := normalizeTaskLabels(task); err != nil {
+		return nil, err
+	}
+	if err := normalizeTaskReminders(task); err != nil {
+		return nil, err
+	}
+
+	current, err := s.repo.GetTask(ctx, userID, task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.workflow(ctx, current.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStatus(workflow, task, current); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkBlockers(ctx, task, current); err != nil {
+		return nil, err
+	}
+
+	if task.Recurrence != "" {
+		if err := s.planSeries(ctx, task, current, future); err != nil {
+			return nil, err
+		}
+	}
+
+	updatedTask, err := s.repo.UpdateTask(ctx, task, future)
 	if err != nil {
 		logger.Error("Failed to update task", zap.Error(err), zap.String("module", "skillsrock"))
 		return nil, err
 	}
 
+	if updatedTask.Done() {
+		s.completeAncestors(ctx, updatedTask.ParentID)
+		s.advanceSeries(ctx, updatedTask)
+	}
+
 	return updatedTask, nil
 }
 
-func (s *TaskService) DeleteTask(ctx context.Context, task_id string) error {
-	err := s.repo.DeleteTask(ctx, task_id)
+// DeleteTask deletes the task and, depending on children, its subtasks or
+// moves them to its parent.
+func (s *TaskService) DeleteTask(ctx context.Context, task_id string, children string) error {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if children == "" {
+		children = domain.ChildrenCascade
+	}
+	if !domain.ValidChildrenMode(children) {
+		return domain.InvalidChildrenMode
+	}
+
+	parentID, err := s.repo.DeleteTask(ctx, userID, task_id, children == domain.ChildrenReparent)
 	if err != nil {
 		logger.Error("Failed to delete task", zap.Error(err), zap.String("module", "skillsrock"))
 		return err
 	}
 
+	s.completeAncestors(ctx, parentID)
+
 	return nil
 }
 
-func (s *TaskService) GetAnalytics(ctx context.Context) (*domain.Analyse, error) {
-	analytics, err := s.repo.GetCachedAnalytics(ctx)
+func (s *TaskService) GetAnalytics(ctx context.Context, projectID int) (*domain.Analyse, error) {
+	scope, err := s.scope(ctx, projectID, domain.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics, err := s.repo.GetCachedAnalytics(ctx, scope)
 	if err != nil {
 		logger.Error("Failed to get analytics", zap.Error(err), zap.String("module", "skillsrock"))
 		return nil, err
@@ -73,8 +248,32 @@ func (s *TaskService) GetAnalytics(ctx context.Context) (*domain.Analyse, error)
 	return analytics, nil
 }
 
-func (s *TaskService) ImportTasks(ctx context.Context, task []*domain.Task) error {
-	err := s.repo.ImportTasks(ctx, task)
+func (s *TaskService) ImportTasks(ctx context.Context, projectID int, task []*domain.Task) error {
+	scope, err := s.scope(ctx, projectID, domain.ProjectRoleEditor)
+	if err != nil {
+		return err
+	}
+
+	workflow, err := s.workflow(ctx, scope.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range task {
+		if err := normalizeTaskLabels(t); err != nil {
+			return err
+		}
+		if err := normalizeTaskReminders(t); err != nil {
+			return err
+		}
+		if err := checkStatus(workflow, t, nil); err != nil {
+			return err
+		}
+		t.UserID = scope.UserID
+		t.ProjectID = scope.ProjectID
+	}
+
+	err = s.repo.ImportTasks(ctx, task)
 	if err != nil {
 		logger.Error("Failed to import tasks", zap.Error(err), zap.String("module", "skillsrock"))
 		return err
@@ -82,8 +281,13 @@ func (s *TaskService) ImportTasks(ctx context.Context, task []*domain.Task) erro
 	return nil
 }
 
-func (s *TaskService) ExportTasks(ctx context.Context) ([]*domain.Task, error) {
-	tasks, err := s.repo.ExportTasks(ctx)
+func (s *TaskService) ExportTasks(ctx context.Context, projectID int) ([]*domain.Task, error) {
+	scope, err := s.scope(ctx, projectID, domain.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.repo.ExportTasks(ctx, scope)
 	if err != nil {
 		logger.Error("Failed to export tasks", zap.Error(err), zap.String("module", "skillsrock"))
 		return nil, err
@@ -95,12 +299,12 @@ func (s *TaskService) analyseWorker(updateInterval time.Duration, retryCount int
 	tick := time.NewTicker(updateInterval)
 	defer tick.Stop()
 	for range tick.C {
-		var analitics *domain.Analyse
+		var scopes []domain.TaskScope
 		var err error
 		for range retryCount {
-			analitics, err = s.repo.GetAnalytics(context.Background())
+			scopes, err = s.repo.GetAnalyticsScopes(context.Background())
 			if err != nil {
-				logger.Error("Failed to get analytics", zap.Error(err), zap.String("module", "skillsrock"))
+				logger.Error("Failed to get analytics scopes", zap.Error(err), zap.String("module", "skillsrock"))
 				time.Sleep(retryInterval)
 				continue
 			} else {
@@ -108,15 +312,37 @@ func (s *TaskService) analyseWorker(updateInterval time.Duration, retryCount int
 			}
 		}
 
-		for range retryCount {
-			err = s.repo.SetAnalytics(context.Background(), analitics)
-			if err != nil {
-				logger.Error("Failed to set analytics", zap.Error(err), zap.String("module", "skillsrock"))
-				time.Sleep(retryInterval)
-				continue
-			} else {
-				break
-			}
+		for _, scope := range scopes {
+			s.refreshAnalytics(scope, retryCount, retryInterval)
+		}
+	}
+}
+
+func (s *TaskService) refreshAnalytics(scope domain.TaskScope, retryCount int, retryInterval time.Duration) {
+	var analitics *domain.Analyse
+	var err error
+	for range retryCount {
+		analitics, err = s.repo.GetAnalytics(context.Background(), scope)
+		if err != nil {
+			logger.Error("Failed to get analytics", zap.Error(err), zap.String("module", "skillsrock"))
+			time.Sleep(retryInterval)
+			continue
+		} else {
+			break
+		}
+	}
+	if err != nil {
+		return
+	}
+
+	for range retryCount {
+		err = s.repo.SetAnalytics(context.Background(), scope, analitics)
+		if err != nil {
+			logger.Error("Failed to set analytics", zap.Error(err), zap.String("module", "skillsrock"))
+			time.Sleep(retryInterval)
+			continue
+		} else {
+			break
 		}
 	}
 }
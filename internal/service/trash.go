@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// GetTrash returns the deleted tasks of the caller or of the project.
+func (s *TaskService) GetTrash(ctx context.Context, projectID int) ([]*domain.Task, error) {
+	scope, err := s.scope(ctx, projectID, domain.ProjectRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.repo.GetTrash(ctx, scope)
+	if err != nil {
+		logger.Error("Failed to get trash", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// RestoreTask takes a deleted task and its subtasks out of the trash.
+func (s *TaskService) RestoreTask(ctx context.Context, taskID int) (*domain.Task, error) {
+	userID, err := domain.UserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.repo.RestoreTask(ctx, userID, taskID)
+	if err != nil {
+		if !errors.Is(err, domain.TaskNotFound) {
+			logger.Error("Failed to restore task", zap.Error(err), zap.String("module", "skillsrock"))
+		}
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func (s *TaskService) purgeWorker(updateInterval time.Duration) {
+	tick := time.NewTicker(updateInterval)
+	defer tick.Stop()
+	for range tick.C {
+		purged, err := s.repo.PurgeTasks(context.Background(), s.trashRetention)
+		if err != nil {
+			logger.Error("Failed to purge trash", zap.Error(err), zap.String("module", "skillsrock"))
+			continue
+		}
+		if purged > 0 {
+			logger.Info("Purged trash", zap.Int64("tasks", purged), zap.String("module", "skillsrock"))
+		}
+	}
+}
@@ -2,24 +2,46 @@ package service
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/wazwki/skillsrock/internal/domain"
 	"github.com/wazwki/skillsrock/internal/repository"
 	"github.com/wazwki/skillsrock/pkg/hashutil"
+	"github.com/wazwki/skillsrock/pkg/jwtutil"
 	"github.com/wazwki/skillsrock/pkg/logger"
+	"github.com/wazwki/skillsrock/pkg/metrics"
+	"github.com/wazwki/skillsrock/pkg/oidc"
 	"go.uber.org/zap"
 )
 
+// LockoutConfig locks logins to an account for Duration after MaxFailures
+// failed attempts. Every further lockout doubles the duration.
+type LockoutConfig struct {
+	MaxFailures int
+	Duration    time.Duration
+}
+
 type UserService struct {
-	repo repository.UserRepositoryInterface
+	repo       repository.UserRepositoryInterface
+	tokens     repository.TokenRepositoryInterface
+	attempts   repository.LoginAttemptRepositoryInterface
+	mfa        repository.MFARepositoryInterface
+	identities repository.IdentityRepositoryInterface
+	jwt        *jwtutil.JWTUtil
+	hasher     *hashutil.Hasher
+	oidc       *oidc.Client
+	lockout    LockoutConfig
 }
 
-func NewUserService(repo repository.UserRepositoryInterface) UserServiceInterface {
-	return &UserService{repo: repo}
+// NewUserService creates the user service. oidcClient may be nil when no
+// identity provider is configured.
+func NewUserService(repo repository.UserRepositoryInterface, tokens repository.TokenRepositoryInterface, attempts repository.LoginAttemptRepositoryInterface, mfa repository.MFARepositoryInterface, identities repository.IdentityRepositoryInterface, jwt *jwtutil.JWTUtil, hasher *hashutil.Hasher, oidcClient *oidc.Client, lockout LockoutConfig) UserServiceInterface {
+	return &UserService{repo: repo, tokens: tokens, attempts: attempts, mfa: mfa, identities: identities, jwt: jwt, hasher: hasher, oidc: oidcClient, lockout: lockout}
 }
 
 func (s *UserService) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
-	hashed, err := hashutil.HashPassword(user.Password)
+	hashed, err := s.hasher.Hash(user.Password)
 	if err != nil {
 		logger.Error("Failed to hash password", zap.Error(err), zap.String("module", "skillsrock"))
 		return nil, err
@@ -29,16 +51,309 @@ func (s *UserService) CreateUser(ctx context.Context, user *domain.User) (*domai
 	return s.repo.CreateUser(ctx, user)
 }
 
-func (s *UserService) CheckUser(ctx context.Context, user *domain.User) error {
+func (s *UserService) CheckUser(ctx context.Context, user *domain.User) (*domain.User, error) {
 	dbUser, err := s.repo.CheckUser(ctx, user)
 	if err != nil {
 		logger.Error("Failed to check user", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	if !s.hasher.Compare(dbUser.Password, user.Password) {
+		return nil, domain.UserNotFound
+	}
+
+	if s.hasher.NeedsRehash(dbUser.Password) {
+		s.rehashPassword(ctx, dbUser, user.Password)
+	}
+
+	return dbUser, nil
+}
+
+// rehashPassword upgrades a hash made with an older algorithm or weaker
+// parameters while the plain password is at hand. Failures only delay the
+// upgrade to the next login.
+func (s *UserService) rehashPassword(ctx context.Context, user *domain.User, password string) {
+	hashed, err := s.hasher.Hash(password)
+	if err != nil {
+		logger.Error("Failed to hash password", zap.Error(err), zap.String("module", "skillsrock"))
+		return
+	}
+
+	if err := s.repo.RehashPassword(ctx, user.ID, user.Password, hashed); err != nil {
+		logger.Error("Failed to rehash password", zap.Error(err), zap.String("module", "skillsrock"))
+		return
+	}
+
+	user.Password = hashed
+}
+
+func (s *UserService) Login(ctx context.Context, user *domain.User) (*domain.Tokens, error) {
+	if err := s.checkLockout(ctx, user.Name, "/api/v1/auth/login"); err != nil {
+		return nil, err
+	}
+
+	dbUser, err := s.CheckUser(ctx, user)
+	if err != nil {
+		if errors.Is(err, domain.UserNotFound) {
+			s.recordLoginFailure(ctx, user.Name)
+		}
+		return nil, err
+	}
+
+	if dbUser.Disabled {
+		return nil, domain.UserDisabled
+	}
+	if dbUser.PasswordResetRequired {
+		return nil, domain.PasswordResetRequired
+	}
+
+	// Failures are only forgotten once the second factor is verified too,
+	// otherwise knowing the password would allow unlimited code guessing.
+	if dbUser.MFAEnabled {
+		return s.mfaChallenge(ctx, dbUser)
+	}
+
+	return s.startSession(ctx, dbUser)
+}
+
+// mfaChallenge returns only an MFA token, to be exchanged at VerifyMFA.
+func (s *UserService) mfaChallenge(ctx context.Context, user *domain.User) (*domain.Tokens, error) {
+	mfaToken, err := s.jwt.GenerateMFAToken(ctx, user.ID)
+	if err != nil {
+		logger.Error("Failed to generate mfa token", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return &domain.Tokens{MFAToken: mfaToken, MFAExpiresAt: time.Now().Add(s.jwt.MFATokenTTL())}, nil
+}
+
+// VerifyMFA completes a login started with Login by checking the second
+// factor of the user the MFA token was issued to.
+func (s *UserService) VerifyMFA(ctx context.Context, mfaToken, code string) (*domain.Tokens, error) {
+	claims, err := s.jwt.ValidateMFAToken(ctx, mfaToken)
+	if err != nil {
+		return nil, domain.InvalidToken
+	}
+
+	user, err := s.repo.GetUser(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, domain.UserNotFound) {
+			return nil, domain.InvalidToken
+		}
+		logger.Error("Failed to get user", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+	if user.Disabled {
+		return nil, domain.UserDisabled
+	}
+
+	if err := s.checkLockout(ctx, user.Name, "/api/v1/auth/mfa"); err != nil {
+		return nil, err
+	}
+
+	if err := verifySecondFactor(ctx, s.mfa, user.ID, code); err != nil {
+		switch {
+		case errors.Is(err, domain.InvalidMFACode):
+			s.recordLoginFailure(ctx, user.Name)
+		case errors.Is(err, domain.MFANotEnrolled):
+			// Two-factor authentication was turned off after the password step.
+			return nil, domain.InvalidToken
+		default:
+			logger.Error("Failed to verify second factor", zap.Error(err), zap.String("module", "skillsrock"))
+		}
+		return nil, err
+	}
+
+	return s.startSession(ctx, user)
+}
+
+func (s *UserService) checkLockout(ctx context.Context, name, path string) error {
+	retryAfter, err := s.attempts.GetLockout(ctx, name)
+	if err != nil {
+		logger.Error("Failed to check login lockout", zap.Error(err), zap.String("module", "skillsrock"))
 		return err
 	}
+	if retryAfter > 0 {
+		metrics.BlockedAuthAttempts.WithLabelValues(path, "lockout").Inc()
+		return &domain.LockedOutError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// startSession issues a token pair in a new refresh token family.
+func (s *UserService) startSession(ctx context.Context, user *domain.User) (*domain.Tokens, error) {
+	if err := s.attempts.ResetLoginFailures(ctx, user.Name); err != nil {
+		logger.Error("Failed to reset login failures", zap.Error(err), zap.String("module", "skillsrock"))
+	}
 
-	if hashutil.ComparePassword(dbUser.Password, user.Password) {
-		return nil
+	family, err := jwtutil.NewTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, claims, err := s.issueTokens(ctx, user, family)
+	if err != nil {
+		return nil, err
+	}
+
+	ip, userAgent := domain.ClientFromContext(ctx)
+	now := time.Now()
+	session := &domain.Session{
+		ID:         family,
+		UserID:     user.ID,
+		IP:         ip,
+		UserAgent:  truncate(userAgent, maxUserAgentLength),
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+
+	if err := s.tokens.CreateRefreshFamily(ctx, session, claims.ID, s.jwt.RefreshTokenTTL()); err != nil {
+		logger.Error("Failed to store refresh token family", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+func (s *UserService) recordLoginFailure(ctx context.Context, name string) {
+	lockout, err := s.attempts.RecordLoginFailure(ctx, name, s.lockout.MaxFailures, s.lockout.Duration)
+	if err != nil {
+		logger.Error("Failed to record login failure", zap.Error(err), zap.String("module", "skillsrock"))
+		return
+	}
+	if lockout > 0 {
+		logger.Warn("Login locked after repeated failures", zap.String("name", name), zap.Duration("lockout", lockout), zap.String("module", "skillsrock"))
+	}
+}
+
+// Refresh exchanges a refresh token for a new token pair. The presented token
+// is retired on every use; presenting it again revokes its whole family.
+func (s *UserService) Refresh(ctx context.Context, refreshToken string) (*domain.Tokens, error) {
+	claims, err := s.jwt.ValidateRefreshToken(ctx, refreshToken)
+	if err != nil || claims.Family == "" {
+		return nil, domain.InvalidToken
+	}
+
+	// The user is reloaded so that role changes take effect on refresh.
+	user, err := s.repo.GetUser(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, domain.UserNotFound) {
+			return nil, domain.InvalidToken
+		}
+		logger.Error("Failed to get user", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+	if user.Disabled {
+		return nil, domain.UserDisabled
+	}
+	if user.PasswordResetRequired {
+		return nil, domain.PasswordResetRequired
+	}
+
+	tokens, newClaims, err := s.issueTokens(ctx, user, claims.Family)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tokens.RotateRefreshFamily(ctx, claims.UserID, claims.Family, claims.ID, newClaims.ID, s.jwt.RefreshTokenTTL())
+	if err != nil {
+		if errors.Is(err, domain.TokenReused) {
+			logger.Warn("Refresh token reuse detected, family revoked", zap.Int("user_id", claims.UserID), zap.String("module", "skillsrock"))
+		} else if !errors.Is(err, domain.InvalidToken) {
+			logger.Error("Failed to rotate refresh token", zap.Error(err), zap.String("module", "skillsrock"))
+		}
+		return nil, err
+	}
+
+	ip, _ := domain.ClientFromContext(ctx)
+	if err := s.tokens.TouchSession(ctx, claims.Family, ip, time.Now(), s.jwt.RefreshTokenTTL()); err != nil {
+		logger.Error("Failed to update session", zap.Error(err), zap.String("module", "skillsrock"))
+	}
+
+	return tokens, nil
+}
+
+// Authenticate validates an access token and checks it against the
+// revocation list.
+func (s *UserService) Authenticate(ctx context.Context, accessToken string) (*jwtutil.CustomClaims, error) {
+	claims, err := s.jwt.ValidateAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, domain.InvalidToken
+	}
+
+	revoked, err := s.tokens.IsTokenRevoked(ctx, claims.ID, claims.Family)
+	if err != nil {
+		logger.Error("Failed to check token revocation", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+	if revoked {
+		return nil, domain.InvalidToken
+	}
+
+	return claims, nil
+}
+
+// Logout revokes the presented access token and its refresh token family.
+func (s *UserService) Logout(ctx context.Context, claims *jwtutil.CustomClaims) error {
+	if err := s.revokeAccessToken(ctx, claims); err != nil {
+		return err
+	}
+
+	if err := s.tokens.DeleteRefreshFamily(ctx, claims.UserID, claims.Family); err != nil {
+		logger.Error("Failed to delete refresh token family", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	return nil
+}
+
+// LogoutAll revokes the presented access token and every refresh token family
+// of its user, which invalidates the access tokens issued from them too.
+func (s *UserService) LogoutAll(ctx context.Context, claims *jwtutil.CustomClaims) error {
+	if err := s.revokeAccessToken(ctx, claims); err != nil {
+		return err
+	}
+
+	if err := s.tokens.DeleteUserRefreshFamilies(ctx, claims.UserID); err != nil {
+		logger.Error("Failed to delete refresh token families", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	return nil
+}
+
+func (s *UserService) revokeAccessToken(ctx context.Context, claims *jwtutil.CustomClaims) error {
+	if claims == nil || claims.ID == "" || claims.ExpiresAt == nil {
+		return domain.InvalidToken
+	}
+
+	if err := s.tokens.RevokeToken(ctx, claims.ID, time.Until(claims.ExpiresAt.Time)); err != nil {
+		logger.Error("Failed to revoke token", zap.Error(err), zap.String("module", "skillsrock"))
+		return err
+	}
+
+	return nil
+}
+
+func (s *UserService) issueTokens(ctx context.Context, user *domain.User, family string) (*domain.Tokens, *jwtutil.CustomClaims, error) {
+	now := time.Now()
+
+	accessToken, err := s.jwt.GenerateAccessToken(ctx, user.ID, user.Role, family)
+	if err != nil {
+		logger.Error("Failed to generate access token", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, nil, err
+	}
+
+	refreshToken, claims, err := s.jwt.GenerateRefreshToken(ctx, user.ID, family)
+	if err != nil {
+		logger.Error("Failed to generate refresh token", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, nil, err
 	}
 
-	return domain.UserNotFound
+	return &domain.Tokens{
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		AccessExpiresAt:  now.Add(s.jwt.AccessTokenTTL()),
+		RefreshExpiresAt: now.Add(s.jwt.RefreshTokenTTL()),
+	}, claims, nil
 }
@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/repository"
+	"github.com/wazwki/skillsrock/internal/service"
+	"github.com/wazwki/skillsrock/pkg/jwtutil"
+)
+
+// fakeUsers serves users from memory. Methods not overridden panic, which
+// keeps the tests honest about what they touch.
+type fakeUsers struct {
+	repository.UserRepositoryInterface
+	users   map[int]*domain.User
+	deleted []int
+}
+
+func (r *fakeUsers) GetUser(ctx context.Context, userID int) (*domain.User, error) {
+	user, ok := r.users[userID]
+	if !ok {
+		return nil, domain.UserNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (r *fakeUsers) DeleteUser(ctx context.Context, userID int) error {
+	r.deleted = append(r.deleted, userID)
+	return nil
+}
+
+type fakeTokens struct {
+	repository.TokenRepositoryInterface
+	sessions map[string]*domain.Session
+	rotated  int
+}
+
+func (r *fakeTokens) GetSession(ctx context.Context, family string) (*domain.Session, error) {
+	session, ok := r.sessions[family]
+	if !ok {
+		return nil, domain.SessionNotFound
+	}
+	return session, nil
+}
+
+func (r *fakeTokens) RotateRefreshFamily(ctx context.Context, userID int, family string, oldTokenID, newTokenID string, ttl time.Duration) error {
+	r.rotated++
+	return nil
+}
+
+func (r *fakeTokens) TouchSession(ctx context.Context, family string, ip string, seenAt time.Time, ttl time.Duration) error {
+	return nil
+}
+
+func (r *fakeTokens) DeleteUserRefreshFamilies(ctx context.Context, userID int) error {
+	return nil
+}
+
+func newJWT(t *testing.T) *jwtutil.JWTUtil {
+	jwt, err := jwtutil.NewJWTUtil(jwtutil.Config{
+		AccessTokenSecret:  []byte("access"),
+		RefreshTokenSecret: []byte("refresh"),
+		AccessTokenTTL:     time.Minute,
+		RefreshTokenTTL:    time.Hour,
+	})
+	require.NoError(t, err)
+	return jwt
+}
+
+func TestRefreshPasswordResetRequired(t *testing.T) {
+	jwt := newJWT(t)
+	users := &fakeUsers{users: map[int]*domain.User{
+		1: {ID: 1, Name: "test", Role: "user", PasswordResetRequired: true},
+		2: {ID: 2, Name: "other", Role: "user"},
+	}}
+	tokens := &fakeTokens{}
+	s := service.NewUserService(users, tokens, nil, nil, nil, jwt, nil, nil, service.LockoutConfig{})
+
+	refreshToken, _, err := jwt.GenerateRefreshToken(context.Background(), 1, "family")
+	require.NoError(t, err)
+
+	_, err = s.Refresh(context.Background(), refreshToken)
+	assert.ErrorIs(t, err, domain.PasswordResetRequired)
+	assert.Zero(t, tokens.rotated)
+
+	refreshToken, _, err = jwt.GenerateRefreshToken(context.Background(), 2, "other")
+	require.NoError(t, err)
+
+	issued, err := s.Refresh(context.Background(), refreshToken)
+	if assert.NoError(t, err) {
+		assert.NotEmpty(t, issued.AccessToken)
+		assert.Equal(t, 1, tokens.rotated)
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/wazwki/skillsrock/internal/domain"
+	"github.com/wazwki/skillsrock/internal/repository"
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type WorkflowService struct {
+	repo     repository.WorkflowRepositoryInterface
+	projects repository.ProjectRepositoryInterface
+}
+
+func NewWorkflowService(repo repository.WorkflowRepositoryInterface, projects repository.ProjectRepositoryInterface) WorkflowServiceInterface {
+	return &WorkflowService{repo: repo, projects: projects}
+}
+
+// checkStatus validates the status of the task against the workflow of its
+// project and fills in its category. current is the stored task on updates,
+// where an empty status keeps the current one and changes have to follow a
+// transition of the workflow. New tasks without a status start in the
+// initial status.
+func checkStatus(workflow *domain.Workflow, task, current *domain.Task) error {
+	if task.Status == "" {
+		task.Status = workflow.Initial()
+		if current != nil {
+			task.Status = current.Status
+		}
+	}
+
+	status := workflow.Status(task.Status)
+	if status == nil {
+		return domain.InvalidStatus
+	}
+	if current != nil && !workflow.CanTransition(current.Status, task.Status) {
+		return domain.InvalidTransition
+	}
+
+	task.StatusCategory = status.Category
+	return nil
+}
+
+// workflowScope checks that the caller may change the workflow: owners for a
+// project, admins for the default workflow.
+func (s *WorkflowService) workflowScope(ctx context.Context, projectID int) error {
+	if projectID == 0 {
+		_, err := requireAdmin(ctx)
+		return err
+	}
+
+	_, err := projectScope(ctx, s.projects, projectID, domain.ProjectRoleOwner)
+	return err
+}
+
+func (s *WorkflowService) GetWorkflow(ctx context.Context, projectID int) (*domain.Workflow, error) {
+	if _, err := projectScope(ctx, s.projects, projectID, domain.ProjectRoleViewer); err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.repo.GetWorkflow(ctx, projectID)
+	if err != nil {
+		logger.Error("Failed to get workflow", zap.Error(err), zap.String("module", "skillsrock"))
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+func (s *WorkflowService) SetWorkflow(ctx context.Context, workflow *domain.Workflow) (*domain.Workflow, error) {
+	if !domain.NormalizeWorkflow(workflow) {
+		return nil, domain.InvalidWorkflow
+	}
+
+	if err := s.workflowScope(ctx, workflow.ProjectID); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.SetWorkflow(ctx, workflow)
+	if err != nil {
+		if !errors.Is(err, domain.StatusInUse) {
+			logger.Error("Failed to set workflow", zap.Error(err), zap.String("module", "skillsrock"))
+		}
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// ResetWorkflow returns a project to the default workflow.
+func (s *WorkflowService) ResetWorkflow(ctx context.Context, projectID int) error {
+	if projectID == 0 {
+		return domain.InvalidWorkflow
+	}
+
+	if err := s.workflowScope(ctx, projectID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteWorkflow(ctx, projectID); err != nil {
+		if !errors.Is(err, domain.StatusInUse) {
+			logger.Error("Failed to reset workflow", zap.Error(err), zap.String("module", "skillsrock"))
+		}
+		return err
+	}
+
+	return nil
+}
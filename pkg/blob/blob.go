@@ -0,0 +1,49 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// BlobStore keeps opaque contents under keys made of letters, digits, '-',
+// '_', '.' and '/' separated segments.
+type BlobStore interface {
+	// Put stores size bytes read from r under key.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get returns ErrNotFound for unknown keys.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete succeeds for unknown keys.
+	Delete(ctx context.Context, key string) error
+}
+
+// New returns the store of the given kind: "local" (default), which keeps
+// blobs below dir, or "s3".
+func New(kind, dir string, s3 S3Config) (BlobStore, error) {
+	switch kind {
+	case "", "local":
+		return NewLocalStore(dir)
+	case "s3":
+		return NewS3Store(s3)
+	}
+	return nil, fmt.Errorf("unknown blob store %q", kind)
+}
+
+var keySegment = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+func validKey(key string) error {
+	for _, segment := range strings.Split(key, "/") {
+		if !keySegment.MatchString(segment) || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
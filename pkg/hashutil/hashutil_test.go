@@ -0,0 +1,60 @@
+package hashutil_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wazwki/skillsrock/pkg/hashutil"
+)
+
+var testArgon2 = hashutil.Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1}
+
+func TestArgon2id(t *testing.T) {
+	h, err := hashutil.NewHasher(hashutil.Config{Algorithm: hashutil.Argon2id, Argon2: testArgon2})
+	require.NoError(t, err)
+
+	hash, err := h.Hash("password")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	assert.True(t, h.Compare(hash, "password"))
+	assert.False(t, h.Compare(hash, "wrong"))
+	assert.False(t, h.NeedsRehash(hash))
+
+	stronger, err := hashutil.NewHasher(hashutil.Config{Algorithm: hashutil.Argon2id, Argon2: hashutil.Argon2Params{Memory: 2048, Iterations: 1, Parallelism: 1}})
+	require.NoError(t, err)
+	assert.True(t, stronger.Compare(hash, "password"))
+	assert.True(t, stronger.NeedsRehash(hash))
+}
+
+func TestBcryptUpgrade(t *testing.T) {
+	legacy, err := hashutil.NewHasher(hashutil.Config{Algorithm: hashutil.Bcrypt, BcryptCost: 4})
+	require.NoError(t, err)
+
+	hash, err := legacy.Hash("password")
+	require.NoError(t, err)
+	assert.False(t, legacy.NeedsRehash(hash))
+
+	h, err := hashutil.NewHasher(hashutil.Config{Algorithm: hashutil.Argon2id, Argon2: testArgon2})
+	require.NoError(t, err)
+	assert.True(t, h.Compare(hash, "password"))
+	assert.False(t, h.Compare(hash, "wrong"))
+	assert.True(t, h.NeedsRehash(hash))
+}
+
+func TestMalformedHash(t *testing.T) {
+	h, err := hashutil.NewHasher(hashutil.Config{Argon2: testArgon2})
+	require.NoError(t, err)
+
+	for _, hash := range []string{"", "plain", "$argon2id$v=19$m=1024,t=1,p=1$c2FsdA$", "$argon2i$v=19$m=1024,t=1,p=1$c2FsdA$a2V5"} {
+		assert.False(t, h.Compare(hash, "password"), hash)
+		assert.True(t, h.NeedsRehash(hash), hash)
+	}
+}
+
+func TestUnknownAlgorithm(t *testing.T) {
+	_, err := hashutil.NewHasher(hashutil.Config{Algorithm: "md5"})
+	assert.Error(t, err)
+}
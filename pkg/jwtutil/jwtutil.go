@@ -2,8 +2,13 @@ package jwtutil
 
 import (
 	"context"
+	"crypto"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -14,45 +19,135 @@ type Config struct {
 	RefreshTokenSecret []byte
 	AccessTokenTTL     time.Duration
 	RefreshTokenTTL    time.Duration
-	SigningMethod      jwt.SigningMethod
+	// MFATokenTTL is how long a login may wait for the second factor, five
+	// minutes by default.
+	MFATokenTTL   time.Duration
+	SigningMethod jwt.SigningMethod
+	// SigningKey switches access tokens to asymmetric signing, RS256 or EdDSA
+	// depending on the key type, so that other services can verify them with
+	// the published public keys. Refresh tokens stay on RefreshTokenSecret.
+	SigningKey crypto.Signer
+	KeyID      string
+	// VerificationKeys are additional public keys by kid that are still
+	// accepted, e.g. the previous signing key during rotation.
+	VerificationKeys map[string]crypto.PublicKey
 }
 
 type JWTUtil struct {
-	cfg Config
+	cfg          Config
+	accessMethod jwt.SigningMethod
+	keys         map[string]verificationKey
 }
 
-func NewJWTUtil(cfg Config) *JWTUtil {
+func NewJWTUtil(cfg Config) (*JWTUtil, error) {
 	if cfg.SigningMethod == nil {
 		cfg.SigningMethod = jwt.SigningMethodHS256
 	}
-	return &JWTUtil{cfg: cfg}
+	if cfg.MFATokenTTL == 0 {
+		cfg.MFATokenTTL = 5 * time.Minute
+	}
+
+	j := &JWTUtil{cfg: cfg, accessMethod: cfg.SigningMethod, keys: make(map[string]verificationKey)}
+	if cfg.SigningKey == nil {
+		return j, nil
+	}
+
+	if cfg.KeyID == "" {
+		return nil, errors.New("key id is required for asymmetric signing")
+	}
+
+	method, err := signingMethodForKey(cfg.SigningKey.Public())
+	if err != nil {
+		return nil, err
+	}
+	j.accessMethod = method
+	j.keys[cfg.KeyID] = verificationKey{method: method, key: cfg.SigningKey.Public()}
+
+	for kid, key := range cfg.VerificationKeys {
+		if kid == cfg.KeyID {
+			continue
+		}
+		method, err := signingMethodForKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("verification key %s: %w", kid, err)
+		}
+		j.keys[kid] = verificationKey{method: method, key: key}
+	}
+
+	return j, nil
+}
+
+func (j *JWTUtil) AccessTokenTTL() time.Duration {
+	return j.cfg.AccessTokenTTL
+}
+
+func (j *JWTUtil) RefreshTokenTTL() time.Duration {
+	return j.cfg.RefreshTokenTTL
+}
+
+func (j *JWTUtil) MFATokenTTL() time.Duration {
+	return j.cfg.MFATokenTTL
 }
 
 type CustomClaims struct {
-	Type string `json:"type"`
+	Type   string `json:"type"`
+	UserID int    `json:"user_id"`
+	Role   string `json:"role,omitempty"`
+	Family string `json:"fid,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func (j *JWTUtil) GenerateAccessToken(ctx context.Context) (string, error) {
+// GenerateAccessToken issues an access token tied to the refresh token family
+// it was obtained with, so that revoking the family revokes it as well.
+func (j *JWTUtil) GenerateAccessToken(ctx context.Context, userID int, role, family string) (string, error) {
+	tokenID, err := NewTokenID()
+	if err != nil {
+		return "", err
+	}
+
 	claims := CustomClaims{
-		Type: "access",
+		Type:   "access",
+		UserID: userID,
+		Role:   role,
+		Family: family,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			Subject:   strconv.Itoa(userID),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.cfg.AccessTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
-	token := jwt.NewWithClaims(j.cfg.SigningMethod, claims)
-	signedToken, err := token.SignedString(j.cfg.AccessTokenSecret)
+	token := jwt.NewWithClaims(j.accessMethod, claims)
+
+	var signingKey interface{} = j.cfg.AccessTokenSecret
+	if j.cfg.SigningKey != nil {
+		token.Header["kid"] = j.cfg.KeyID
+		signingKey = j.cfg.SigningKey
+	}
+
+	signedToken, err := token.SignedString(signingKey)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign access token: %w", err)
 	}
-	return "Bearer " + signedToken, nil
+	return signedToken, nil
 }
 
-func (j *JWTUtil) GenerateRefreshToken(ctx context.Context) (string, error) {
-	claims := CustomClaims{
-		Type: "refresh",
+// GenerateRefreshToken issues a refresh token belonging to the given token
+// family. Every token gets its own ID so that rotation can tell a fresh token
+// from one that has already been exchanged.
+func (j *JWTUtil) GenerateRefreshToken(ctx context.Context, userID int, family string) (string, *CustomClaims, error) {
+	tokenID, err := NewTokenID()
+	if err != nil {
+		return "", nil, err
+	}
+
+	claims := &CustomClaims{
+		Type:   "refresh",
+		UserID: userID,
+		Family: family,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			Subject:   strconv.Itoa(userID),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.cfg.RefreshTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
@@ -60,23 +155,72 @@ func (j *JWTUtil) GenerateRefreshToken(ctx context.Context) (string, error) {
 	token := jwt.NewWithClaims(j.cfg.SigningMethod, claims)
 	signedToken, err := token.SignedString(j.cfg.RefreshTokenSecret)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign refresh token: %w", err)
+		return "", nil, fmt.Errorf("failed to sign refresh token: %w", err)
+	}
+	return signedToken, claims, nil
+}
+
+// GenerateMFAToken issues a short-lived token proving that the password of
+// the user was verified. It is exchanged for a token pair together with the
+// second factor and is not accepted anywhere else.
+func (j *JWTUtil) GenerateMFAToken(ctx context.Context, userID int) (string, error) {
+	tokenID, err := NewTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	claims := CustomClaims{
+		Type:   "mfa",
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			Subject:   strconv.Itoa(userID),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.cfg.MFATokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(j.cfg.SigningMethod, claims)
+	signedToken, err := token.SignedString(j.cfg.RefreshTokenSecret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign mfa token: %w", err)
+	}
+	return signedToken, nil
+}
+
+// GenerateLinkToken issues a token proving that the user started the
+// authorization request with the given state to link an account at an
+// identity provider. The state is its ID, so it fits no other request.
+func (j *JWTUtil) GenerateLinkToken(ctx context.Context, userID int, state string, ttl time.Duration) (string, error) {
+	claims := CustomClaims{
+		Type:   "link",
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        state,
+			Subject:   strconv.Itoa(userID),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(j.cfg.SigningMethod, claims)
+	signedToken, err := token.SignedString(j.cfg.RefreshTokenSecret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign link token: %w", err)
 	}
 	return signedToken, nil
 }
 
 func (j *JWTUtil) ValidateToken(ctx context.Context, tokenStr string) (*CustomClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenStr, &CustomClaims{}, func(t *jwt.Token) (interface{}, error) {
-		if t.Method != j.cfg.SigningMethod {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-		}
 		claims, ok := t.Claims.(*CustomClaims)
 		if !ok {
 			return nil, errors.New("invalid claims type")
 		}
 		if claims.Type == "access" {
-			return j.cfg.AccessTokenSecret, nil
-		} else if claims.Type == "refresh" {
+			return j.accessVerificationKey(t)
+		} else if claims.Type == "refresh" || claims.Type == "mfa" || claims.Type == "link" {
+			if t.Method != j.cfg.SigningMethod {
+				return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+			}
 			return j.cfg.RefreshTokenSecret, nil
 		}
 		return nil, errors.New("unknown token type")
@@ -92,10 +236,68 @@ func (j *JWTUtil) ValidateToken(ctx context.Context, tokenStr string) (*CustomCl
 	return claims, nil
 }
 
-func (j *JWTUtil) RefreshAccessToken(ctx context.Context, refreshToken string) (string, error) {
-	_, err := j.ValidateToken(ctx, refreshToken)
+func (j *JWTUtil) accessVerificationKey(t *jwt.Token) (interface{}, error) {
+	if j.cfg.SigningKey == nil {
+		if t.Method != j.cfg.SigningMethod {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return j.cfg.AccessTokenSecret, nil
+	}
+
+	kid, _ := t.Header["kid"].(string)
+	key, ok := j.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id: %q", kid)
+	}
+	if t.Method != key.method {
+		return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	}
+	return key.key, nil
+}
+
+// JWKS returns the public keys access tokens can be verified with. It is
+// empty when tokens are signed with a shared secret.
+func (j *JWTUtil) JWKS() JWKSet {
+	set := JWKSet{Keys: make([]JWK, 0, len(j.keys))}
+	for kid, key := range j.keys {
+		set.Keys = append(set.Keys, toJWK(kid, key))
+	}
+	sort.Slice(set.Keys, func(a, b int) bool { return set.Keys[a].Kid < set.Keys[b].Kid })
+	return set
+}
+
+func (j *JWTUtil) ValidateAccessToken(ctx context.Context, tokenStr string) (*CustomClaims, error) {
+	return j.validateTokenType(ctx, tokenStr, "access")
+}
+
+func (j *JWTUtil) ValidateRefreshToken(ctx context.Context, tokenStr string) (*CustomClaims, error) {
+	return j.validateTokenType(ctx, tokenStr, "refresh")
+}
+
+func (j *JWTUtil) ValidateMFAToken(ctx context.Context, tokenStr string) (*CustomClaims, error) {
+	return j.validateTokenType(ctx, tokenStr, "mfa")
+}
+
+func (j *JWTUtil) ValidateLinkToken(ctx context.Context, tokenStr string) (*CustomClaims, error) {
+	return j.validateTokenType(ctx, tokenStr, "link")
+}
+
+func (j *JWTUtil) validateTokenType(ctx context.Context, tokenStr, tokenType string) (*CustomClaims, error) {
+	claims, err := j.ValidateToken(ctx, tokenStr)
 	if err != nil {
-		return "", fmt.Errorf("invalid refresh token: %w", err)
+		return nil, err
+	}
+	if claims.Type != tokenType {
+		return nil, fmt.Errorf("unexpected token type: %s", claims.Type)
+	}
+	return claims, nil
+}
+
+// NewTokenID returns a random identifier suitable for the jti claim and token families.
+func NewTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token id: %w", err)
 	}
-	return j.GenerateAccessToken(ctx)
+	return hex.EncodeToString(b), nil
 }
@@ -0,0 +1,166 @@
+package jwtutil_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wazwki/skillsrock/pkg/jwtutil"
+)
+
+func newConfig() jwtutil.Config {
+	return jwtutil.Config{
+		AccessTokenSecret:  []byte("access"),
+		RefreshTokenSecret: []byte("refresh"),
+		AccessTokenTTL:     time.Minute,
+		RefreshTokenTTL:    time.Hour,
+	}
+}
+
+func TestHMACTokens(t *testing.T) {
+	j, err := jwtutil.NewJWTUtil(newConfig())
+	require.NoError(t, err)
+
+	access, err := j.GenerateAccessToken(context.Background(), 7, "member", "family")
+	require.NoError(t, err)
+
+	claims, err := j.ValidateAccessToken(context.Background(), access)
+	require.NoError(t, err)
+	assert.Equal(t, 7, claims.UserID)
+	assert.Equal(t, "member", claims.Role)
+	assert.Equal(t, "family", claims.Family)
+	assert.NotEmpty(t, claims.ID)
+
+	refresh, _, err := j.GenerateRefreshToken(context.Background(), 7, "family")
+	require.NoError(t, err)
+
+	_, err = j.ValidateAccessToken(context.Background(), refresh)
+	assert.Error(t, err)
+
+	assert.Empty(t, j.JWKS().Keys)
+}
+
+func TestMFATokens(t *testing.T) {
+	j, err := jwtutil.NewJWTUtil(newConfig())
+	require.NoError(t, err)
+
+	mfa, err := j.GenerateMFAToken(context.Background(), 7)
+	require.NoError(t, err)
+
+	claims, err := j.ValidateMFAToken(context.Background(), mfa)
+	require.NoError(t, err)
+	assert.Equal(t, 7, claims.UserID)
+
+	_, err = j.ValidateAccessToken(context.Background(), mfa)
+	assert.Error(t, err)
+	_, err = j.ValidateRefreshToken(context.Background(), mfa)
+	assert.Error(t, err)
+
+	refresh, _, err := j.GenerateRefreshToken(context.Background(), 7, "family")
+	require.NoError(t, err)
+	_, err = j.ValidateMFAToken(context.Background(), refresh)
+	assert.Error(t, err)
+}
+
+func TestLinkTokens(t *testing.T) {
+	j, err := jwtutil.NewJWTUtil(newConfig())
+	require.NoError(t, err)
+
+	link, err := j.GenerateLinkToken(context.Background(), 7, "state", time.Minute)
+	require.NoError(t, err)
+
+	claims, err := j.ValidateLinkToken(context.Background(), link)
+	require.NoError(t, err)
+	assert.Equal(t, 7, claims.UserID)
+	assert.Equal(t, "state", claims.ID)
+
+	_, err = j.ValidateMFAToken(context.Background(), link)
+	assert.Error(t, err)
+
+	mfa, err := j.GenerateMFAToken(context.Background(), 7)
+	require.NoError(t, err)
+	_, err = j.ValidateLinkToken(context.Background(), mfa)
+	assert.Error(t, err)
+
+	expired, err := j.GenerateLinkToken(context.Background(), 7, "state", -time.Minute)
+	require.NoError(t, err)
+	_, err = j.ValidateLinkToken(context.Background(), expired)
+	assert.Error(t, err)
+}
+
+func TestAsymmetricTokens(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	for name, key := range map[string]crypto.Signer{"RS256": rsaKey, "EdDSA": edKey} {
+		t.Run(name, func(t *testing.T) {
+			cfg := newConfig()
+			cfg.SigningKey = key
+			cfg.KeyID = "current"
+			j, err := jwtutil.NewJWTUtil(cfg)
+			require.NoError(t, err)
+
+			access, err := j.GenerateAccessToken(context.Background(), 1, "member", "family")
+			require.NoError(t, err)
+
+			claims, err := j.ValidateAccessToken(context.Background(), access)
+			require.NoError(t, err)
+			assert.Equal(t, 1, claims.UserID)
+
+			jwks := j.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, "current", jwks.Keys[0].Kid)
+			assert.Equal(t, name, jwks.Keys[0].Alg)
+
+			// A token signed with the shared secret must not be accepted.
+			hmac, err := jwtutil.NewJWTUtil(newConfig())
+			require.NoError(t, err)
+			forged, err := hmac.GenerateAccessToken(context.Background(), 1, "member", "family")
+			require.NoError(t, err)
+			_, err = j.ValidateAccessToken(context.Background(), forged)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, newKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	oldCfg := newConfig()
+	oldCfg.SigningKey = oldKey
+	oldCfg.KeyID = "old"
+	oldJWT, err := jwtutil.NewJWTUtil(oldCfg)
+	require.NoError(t, err)
+
+	token, err := oldJWT.GenerateAccessToken(context.Background(), 1, "member", "family")
+	require.NoError(t, err)
+
+	newCfg := newConfig()
+	newCfg.SigningKey = newKey
+	newCfg.KeyID = "new"
+	newCfg.VerificationKeys = map[string]crypto.PublicKey{"old": oldKey.Public()}
+	newJWT, err := jwtutil.NewJWTUtil(newCfg)
+	require.NoError(t, err)
+
+	_, err = newJWT.ValidateAccessToken(context.Background(), token)
+	assert.NoError(t, err)
+	assert.Len(t, newJWT.JWKS().Keys, 2)
+
+	newCfg.VerificationKeys = nil
+	retiredJWT, err := jwtutil.NewJWTUtil(newCfg)
+	require.NoError(t, err)
+
+	_, err = retiredJWT.ValidateAccessToken(context.Background(), token)
+	assert.Error(t, err)
+}
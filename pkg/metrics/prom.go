@@ -13,8 +13,16 @@ var (
 		},
 		[]string{"method", "path"},
 	)
+
+	BlockedAuthAttempts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "auth_blocked_attempts_total",
+			Help: "Authentication requests rejected by rate limiting or account lockout.",
+		},
+		[]string{"path", "reason"},
+	)
 )
 
 func init() {
-	prometheus.MustRegister(ObserveRequestDuration)
+	prometheus.MustRegister(ObserveRequestDuration, BlockedAuthAttempts)
 }
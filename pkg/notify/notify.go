@@ -0,0 +1,102 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/wazwki/skillsrock/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ErrNoAddress is returned by notifiers that mail messages when the
+// recipient has no email address.
+var ErrNoAddress = errors.New("recipient has no email address")
+
+type Message struct {
+	// To names the recipient: their email address if they have one.
+	To string `json:"to"`
+	// Email is the address of the recipient, empty if they have none. Mail is
+	// only ever sent to it, never to To.
+	Email   string `json:"email,omitempty"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Notifier delivers messages to users.
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// New returns the notifier of the given kind: "log" (default), "file", which
+// appends messages as JSON lines to path, "webhook" or "smtp".
+func New(kind, path string, webhook WebhookConfig, smtp SMTPConfig) (Notifier, error) {
+	switch kind {
+	case "webhook":
+		return NewWebhookNotifier(webhook)
+	case "smtp":
+		return NewSMTPNotifier(smtp)
+	}
+	return NewLocal(kind, path)
+}
+
+// NewLocal returns a notifier that keeps messages on this host: "log"
+// (default) or "file". It is meant for secrets that must not leave it.
+func NewLocal(kind, path string) (Notifier, error) {
+	switch kind {
+	case "", "log":
+		return NewLogNotifier(), nil
+	case "file":
+		if path == "" {
+			return nil, fmt.Errorf("file notifier requires a path")
+		}
+		return NewFileNotifier(path), nil
+	}
+	return nil, fmt.Errorf("unknown notifier %q", kind)
+}
+
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, msg Message) error {
+	logger.Info("Notification", zap.String("to", msg.To), zap.String("subject", msg.Subject), zap.String("body", msg.Body), zap.String("module", "skillsrock"))
+	return nil
+}
+
+type FileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{path: path}
+}
+
+func (n *FileNotifier) Notify(ctx context.Context, msg Message) error {
+	line, err := json.Marshal(struct {
+		Time time.Time `json:"time"`
+		Message
+	}{Time: time.Now(), Message: msg})
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,185 @@
+package notify_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wazwki/skillsrock/pkg/notify"
+)
+
+func TestFileNotifier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.log")
+	n, err := notify.New("file", path, notify.WebhookConfig{}, notify.SMTPConfig{})
+	require.NoError(t, err)
+
+	require.NoError(t, n.Notify(context.Background(), notify.Message{To: "john", Subject: "first", Body: "1"}))
+	require.NoError(t, n.Notify(context.Background(), notify.Message{To: "john", Subject: "second", Body: "2"}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	var msg notify.Message
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &msg))
+	assert.Equal(t, notify.Message{To: "john", Subject: "second", Body: "2"}, msg)
+}
+
+func TestUnknownNotifier(t *testing.T) {
+	_, err := notify.New("pigeon", "", notify.WebhookConfig{}, notify.SMTPConfig{})
+	assert.Error(t, err)
+}
+
+func TestLocalNotifierRefusesRemote(t *testing.T) {
+	_, err := notify.NewLocal("webhook", "")
+	assert.Error(t, err)
+	_, err = notify.NewLocal("smtp", "")
+	assert.Error(t, err)
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var got notify.Message
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &got))
+
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		signature = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+		if r.Header.Get(notify.SignatureHeader) != signature {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	n, err := notify.New("webhook", "", notify.WebhookConfig{URL: server.URL, Secret: "secret"}, notify.SMTPConfig{})
+	require.NoError(t, err)
+
+	msg := notify.Message{To: "john", Subject: "Reminder", Body: "Due soon"}
+	require.NoError(t, n.Notify(context.Background(), msg))
+	assert.Equal(t, msg, got)
+
+	n, err = notify.New("webhook", "", notify.WebhookConfig{URL: server.URL, Secret: "wrong"}, notify.SMTPConfig{})
+	require.NoError(t, err)
+	assert.Error(t, n.Notify(context.Background(), msg))
+}
+
+// smtpStandIn accepts a single mail on a local listener and sends the
+// envelope recipient and the data on the returned channel.
+func smtpStandIn(t *testing.T) (string, <-chan []string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	mails := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		text := textproto.NewConn(conn)
+		text.PrintfLine("220 localhost ESMTP")
+
+		var rcpt string
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+
+			command := strings.ToUpper(strings.Fields(line + " ")[0])
+			switch command {
+			case "EHLO":
+				text.PrintfLine("250-localhost")
+				text.PrintfLine("250 8BITMIME")
+			case "RCPT":
+				rcpt = line
+				text.PrintfLine("250 OK")
+			case "DATA":
+				text.PrintfLine("354 Go ahead")
+				data, err := text.ReadDotLines()
+				if err != nil {
+					return
+				}
+				text.PrintfLine("250 OK")
+				mails <- append([]string{rcpt}, data...)
+			case "QUIT":
+				text.PrintfLine("221 Bye")
+				return
+			default:
+				text.PrintfLine("250 OK")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), mails
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	addr, mails := smtpStandIn(t)
+
+	n, err := notify.New("smtp", "", notify.WebhookConfig{}, notify.SMTPConfig{Addr: addr, From: "Skillsrock <noreply@example.com>"})
+	require.NoError(t, err)
+
+	err = n.Notify(context.Background(), notify.Message{To: "john@example.com", Email: "john@example.com", Subject: "Напоминание", Body: "Due soon\nReally"})
+	require.NoError(t, err)
+
+	mail := <-mails
+	assert.Equal(t, "RCPT TO:<john@example.com>", mail[0])
+	assert.Contains(t, mail, "To: <john@example.com>")
+	subject, err := new(mime.WordDecoder).DecodeHeader(mail[3])
+	require.NoError(t, err)
+	assert.Equal(t, "Subject: Напоминание", subject)
+	assert.Equal(t, []string{"Due soon", "Really"}, mail[len(mail)-2:])
+
+	// Names are never taken for addresses, even when they look like one.
+	assert.ErrorIs(t, n.Notify(context.Background(), notify.Message{To: "john", Subject: "Reminder"}), notify.ErrNoAddress)
+	assert.ErrorIs(t, n.Notify(context.Background(), notify.Message{To: "john@example.org", Subject: "Reminder"}), notify.ErrNoAddress)
+	assert.Error(t, n.Notify(context.Background(), notify.Message{To: "john", Email: "john", Subject: "Reminder"}))
+}
+
+func TestSMTPNotifierCancelled(t *testing.T) {
+	// The server accepts the connection but never greets.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.Copy(io.Discard, conn)
+	}()
+
+	n, err := notify.New("smtp", "", notify.WebhookConfig{}, notify.SMTPConfig{Addr: listener.Addr().String(), From: "noreply@example.com"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err = n.Notify(ctx, notify.Message{To: "john@example.com", Email: "john@example.com", Subject: "Reminder"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
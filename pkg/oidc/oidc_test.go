@@ -0,0 +1,149 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wazwki/skillsrock/pkg/oidc"
+)
+
+// mockIdP is a minimal identity provider issuing ID tokens for one code.
+type mockIdP struct {
+	*httptest.Server
+	key       *rsa.PrivateKey
+	challenge string
+	nonce     string
+	audience  jwt.ClaimStrings
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &mockIdP{key: key, audience: jwt.ClaimStrings{"client"}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{ //nolint:errcheck
+			"issuer":                 idp.URL,
+			"authorization_endpoint": idp.URL + "/authorize",
+			"token_endpoint":         idp.URL + "/token",
+			"jwks_uri":               idp.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{ //nolint:errcheck
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"use": "sig",
+				"kid": "idp",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		user, secret, _ := r.BasicAuth()
+		sum := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+		if user != "client" || secret != "secret" || r.PostFormValue("code") != "code" ||
+			base64.RawURLEncoding.EncodeToString(sum[:]) != idp.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"}) //nolint:errcheck
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": idp.idToken(t, idp.nonce)}) //nolint:errcheck
+	})
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+
+	return idp
+}
+
+func (idp *mockIdP) idToken(t *testing.T, nonce string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, oidc.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    idp.URL,
+			Subject:   "subject",
+			Audience:  idp.audience,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+		Nonce:         nonce,
+		Email:         "john@example.com",
+		EmailVerified: true,
+	})
+	token.Header["kid"] = "idp"
+
+	signed, err := token.SignedString(idp.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func newClient(idp *mockIdP) *oidc.Client {
+	return oidc.New(oidc.Config{
+		Issuer:       idp.URL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost/callback",
+	})
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	idp := newMockIdP(t)
+	client := newClient(idp)
+
+	verifier, challenge, err := oidc.NewPKCE()
+	require.NoError(t, err)
+	idp.challenge, idp.nonce = challenge, "nonce"
+
+	authURL, err := client.AuthCodeURL(context.Background(), "state", "nonce", challenge)
+	require.NoError(t, err)
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, "/authorize", u.Path)
+	assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+	assert.Equal(t, "state", u.Query().Get("state"))
+
+	rawToken, err := client.Exchange(context.Background(), "code", verifier)
+	require.NoError(t, err)
+
+	claims, err := client.VerifyIDToken(context.Background(), rawToken, "nonce")
+	require.NoError(t, err)
+	assert.Equal(t, "subject", claims.Subject)
+	assert.Equal(t, "john@example.com", claims.Email)
+	assert.True(t, claims.EmailVerified)
+
+	_, err = client.Exchange(context.Background(), "code", "wrong-verifier")
+	assert.Error(t, err)
+}
+
+func TestVerifyIDTokenRejects(t *testing.T) {
+	idp := newMockIdP(t)
+	client := newClient(idp)
+
+	_, err := client.VerifyIDToken(context.Background(), idp.idToken(t, "other"), "nonce")
+	assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+
+	idp.audience = jwt.ClaimStrings{"someone-else"}
+	_, err = client.VerifyIDToken(context.Background(), idp.idToken(t, "nonce"), "nonce")
+	assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	idp.audience = jwt.ClaimStrings{"client"}
+	idp.key = otherKey
+	_, err = client.VerifyIDToken(context.Background(), idp.idToken(t, "nonce"), "nonce")
+	assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+}
@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// slidingWindowScript keeps the timestamps of accepted requests in a sorted
+// set. It returns 0 if the request fits into the window, otherwise the number
+// of milliseconds until the oldest request leaves it.
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+if redis.call("ZCARD", KEYS[1]) < limit then
+	redis.call("ZADD", KEYS[1], now, ARGV[4])
+	redis.call("PEXPIRE", KEYS[1], window)
+	return 0
+end
+local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+return math.max(tonumber(oldest[2]) + window - now, 1)
+`)
+
+// Limiter allows at most Limit requests per key within any Window.
+type Limiter struct {
+	client *redis.Client
+	prefix string
+	Limit  int
+	Window time.Duration
+}
+
+func New(client *redis.Client, prefix string, limit int, window time.Duration) *Limiter {
+	return &Limiter{client: client, prefix: prefix, Limit: limit, Window: window}
+}
+
+// Allow records a request for key. If the limit is exhausted the request is
+// not recorded and the time until the next one is allowed is returned.
+func (l *Limiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	member := make([]byte, 8)
+	if _, err := rand.Read(member); err != nil {
+		return false, 0, err
+	}
+
+	now := time.Now().UnixMilli()
+	wait, err := slidingWindowScript.Run(ctx, l.client, []string{l.prefix + key},
+		now, l.Window.Milliseconds(), l.Limit, strconv.FormatInt(now, 10)+"-"+hex.EncodeToString(member)).Int64()
+	if err != nil {
+		return false, 0, err
+	}
+
+	return wait == 0, time.Duration(wait) * time.Millisecond, nil
+}
+
+// RetryAfter formats d as a Retry-After header value in whole seconds.
+func RetryAfter(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
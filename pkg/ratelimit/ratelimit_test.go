@@ -0,0 +1,68 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wazwki/skillsrock/pkg/ratelimit"
+)
+
+func newLimiter(t *testing.T, limit int, window time.Duration) *ratelimit.Limiter {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return ratelimit.New(client, "test:", limit, window)
+}
+
+func TestAllowBlocksOverLimit(t *testing.T) {
+	limiter := newLimiter(t, 2, time.Minute)
+	ctx := context.Background()
+
+	for range 2 {
+		allowed, _, err := limiter.Allow(ctx, "ip:1.2.3.4")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+
+	allowed, wait, err := limiter.Allow(ctx, "ip:1.2.3.4")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Greater(t, wait, 59*time.Second)
+	assert.LessOrEqual(t, wait, time.Minute)
+	assert.Equal(t, "60", ratelimit.RetryAfter(wait))
+
+	// Other keys have their own window.
+	allowed, _, err = limiter.Allow(ctx, "ip:5.6.7.8")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestAllowWindowExpiry(t *testing.T) {
+	limiter := newLimiter(t, 1, 100*time.Millisecond)
+	ctx := context.Background()
+
+	allowed, _, err := limiter.Allow(ctx, "user:test")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, _, err = limiter.Allow(ctx, "user:test")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	time.Sleep(150 * time.Millisecond)
+
+	allowed, _, err = limiter.Allow(ctx, "user:test")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert.Equal(t, "1", ratelimit.RetryAfter(time.Millisecond))
+	assert.Equal(t, "2", ratelimit.RetryAfter(1500*time.Millisecond))
+	assert.Equal(t, "30", ratelimit.RetryAfter(30*time.Second))
+}
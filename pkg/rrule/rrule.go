@@ -0,0 +1,344 @@
+// Package rrule implements the subset of RFC 5545 recurrence rules used for
+// recurring tasks: FREQ of DAILY, WEEKLY or MONTHLY with INTERVAL, BYDAY and
+// either COUNT or UNTIL. Weeks start on Monday.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// maxEmptyPeriods stops the search for rules that never produce another
+// occurrence, such as the fifth Monday of every twelfth month.
+const maxEmptyPeriods = 1000
+
+const untilLayout = "20060102T150405Z"
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Weekday is a BYDAY entry. N selects the Nth such day of the month, counted
+// from the end when negative, and is only allowed for monthly rules. 0 means
+// every such day.
+type Weekday struct {
+	N   int
+	Day time.Weekday
+}
+
+func (w Weekday) String() string {
+	if w.N == 0 {
+		return weekdayNames[w.Day]
+	}
+	return strconv.Itoa(w.N) + weekdayNames[w.Day]
+}
+
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []Weekday
+	// Count and Until are mutually exclusive, zero values mean unbounded.
+	Count int
+	Until time.Time
+}
+
+func invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidRule, fmt.Sprintf(format, args...))
+}
+
+// Parse reads a rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=10",
+// with or without the "RRULE:" prefix. UNTIL is a UTC date-time, or a date
+// that includes the whole day.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+	if s == "" {
+		return nil, invalid("empty rule")
+	}
+
+	rule := &Rule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, invalid("malformed part %q", part)
+		}
+		if seen[key] {
+			return nil, invalid("duplicate %s", key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			rule.Freq = Frequency(value)
+			if rule.Freq != Daily && rule.Freq != Weekly && rule.Freq != Monthly {
+				return nil, invalid("unsupported FREQ %s", value)
+			}
+		case "INTERVAL":
+			if rule.Interval, err = strconv.Atoi(value); err != nil || rule.Interval < 1 {
+				return nil, invalid("INTERVAL must be a positive number")
+			}
+		case "COUNT":
+			if rule.Count, err = strconv.Atoi(value); err != nil || rule.Count < 1 {
+				return nil, invalid("COUNT must be a positive number")
+			}
+		case "UNTIL":
+			if rule.Until, err = parseUntil(value); err != nil {
+				return nil, err
+			}
+		case "BYDAY":
+			if rule.ByDay, err = parseByDay(value); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, invalid("unsupported part %s", key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, invalid("FREQ is required")
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return nil, invalid("COUNT and UNTIL are mutually exclusive")
+	}
+	if rule.Freq != Monthly {
+		for _, day := range rule.ByDay {
+			if day.N != 0 {
+				return nil, invalid("BYDAY ordinals are only allowed with FREQ=MONTHLY")
+			}
+		}
+	}
+
+	return rule, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse(untilLayout, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("20060102T150405", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, invalid("malformed UNTIL %s", value)
+}
+
+func parseByDay(value string) ([]Weekday, error) {
+	days := make([]Weekday, 0)
+	seen := make(map[Weekday]bool)
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, invalid("malformed BYDAY %q", item)
+		}
+
+		day, ok := weekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, invalid("malformed BYDAY %q", item)
+		}
+
+		var n int
+		if ordinal := item[:len(item)-2]; ordinal != "" {
+			var err error
+			n, err = strconv.Atoi(ordinal)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, invalid("malformed BYDAY %q", item)
+			}
+		}
+
+		weekday := Weekday{N: n, Day: day}
+		if !seen[weekday] {
+			seen[weekday] = true
+			days = append(days, weekday)
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].Day != days[j].Day {
+			return mondayFirst(days[i].Day) < mondayFirst(days[j].Day)
+		}
+		return days[i].N < days[j].N
+	})
+	return days, nil
+}
+
+// mondayFirst numbers the days of the week from 0 for Monday to 6 for Sunday.
+func mondayFirst(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// String returns the canonical form of the rule, suitable for Parse.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			days = append(days, day.String())
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayout))
+	}
+	return strings.Join(parts, ";")
+}
+
+// After returns the first occurrence later than t of the series that starts
+// at start, and false once the series has ended. As in RFC 5545 start is
+// always the first occurrence and counts towards COUNT, whether or not it
+// matches the rule. Occurrences keep the time of day of start.
+func (r *Rule) After(start, t time.Time) (time.Time, bool) {
+	n := 0
+	for occurrence := range r.occurrences(start) {
+		n++
+		if r.Count > 0 && n > r.Count {
+			break
+		}
+		if !r.Until.IsZero() && occurrence.After(r.Until) {
+			break
+		}
+		if occurrence.After(t) {
+			return occurrence, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// occurrences yields start followed by the later occurrences in order.
+func (r *Rule) occurrences(start time.Time) func(yield func(time.Time) bool) {
+	return func(yield func(time.Time) bool) {
+		if !yield(start) {
+			return
+		}
+
+		empty := 0
+		for period := 0; empty < maxEmptyPeriods; period++ {
+			days := r.period(start, period)
+			if len(days) == 0 {
+				empty++
+				continue
+			}
+			empty = 0
+
+			for _, day := range days {
+				occurrence := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+				if !occurrence.After(start) {
+					continue
+				}
+				if !yield(occurrence) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// period returns the days of the given period of the rule in order, as
+// midnight of the day in the location of start.
+func (r *Rule) period(start time.Time, period int) []time.Time {
+	first := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+
+	switch r.Freq {
+	case Daily:
+		day := first.AddDate(0, 0, period*r.Interval)
+		if len(r.ByDay) > 0 && !r.hasWeekday(day.Weekday()) {
+			return nil
+		}
+		return []time.Time{day}
+
+	case Weekly:
+		monday := first.AddDate(0, 0, -mondayFirst(first.Weekday())+7*period*r.Interval)
+		if len(r.ByDay) == 0 {
+			return []time.Time{monday.AddDate(0, 0, mondayFirst(start.Weekday()))}
+		}
+		days := make([]time.Time, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			days = append(days, monday.AddDate(0, 0, mondayFirst(day.Day)))
+		}
+		return days
+
+	case Monthly:
+		month := time.Date(first.Year(), first.Month()+time.Month(period*r.Interval), 1, 0, 0, 0, 0, first.Location())
+		if len(r.ByDay) == 0 {
+			day := month.AddDate(0, 0, start.Day()-1)
+			if day.Month() != month.Month() {
+				return nil
+			}
+			return []time.Time{day}
+		}
+		return r.monthDays(month)
+	}
+
+	return nil
+}
+
+func (r *Rule) hasWeekday(day time.Weekday) bool {
+	for _, weekday := range r.ByDay {
+		if weekday.Day == day {
+			return true
+		}
+	}
+	return false
+}
+
+// monthDays returns the days of the month starting at month that match BYDAY.
+func (r *Rule) monthDays(month time.Time) []time.Time {
+	next := month.AddDate(0, 1, 0)
+	seen := make(map[int]bool)
+	days := make([]time.Time, 0)
+	for _, weekday := range r.ByDay {
+		matches := make([]time.Time, 0, 5)
+		for day := month.AddDate(0, 0, (int(weekday.Day)-int(month.Weekday())+7)%7); day.Before(next); day = day.AddDate(0, 0, 7) {
+			matches = append(matches, day)
+		}
+
+		switch {
+		case weekday.N == 0:
+		case weekday.N > 0 && weekday.N <= len(matches):
+			matches = matches[weekday.N-1 : weekday.N]
+		case weekday.N < 0 && -weekday.N <= len(matches):
+			matches = matches[len(matches)+weekday.N : len(matches)+weekday.N+1]
+		default:
+			matches = nil
+		}
+
+		for _, day := range matches {
+			if !seen[day.Day()] {
+				seen[day.Day()] = true
+				days = append(days, day)
+			}
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	return days
+}
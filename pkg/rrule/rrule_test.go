@@ -0,0 +1,127 @@
+package rrule_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wazwki/skillsrock/pkg/rrule"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// series lists up to n occurrences of the rule started at start.
+func series(t *testing.T, rule, start string, n int) []string {
+	r, err := rrule.Parse(rule)
+	require.NoError(t, err)
+
+	result := make([]string, 0, n)
+	current := date(start)
+	result = append(result, current.Format("2006-01-02 15:04"))
+	for len(result) < n {
+		next, ok := r.After(date(start), current)
+		if !ok {
+			break
+		}
+		result = append(result, next.Format("2006-01-02 15:04"))
+		current = next
+	}
+	return result
+}
+
+func TestParseCanonical(t *testing.T) {
+	tests := map[string]string{
+		"FREQ=DAILY": "FREQ=DAILY",
+		"rrule:freq=weekly;byday=fr,mo;interval=2":   "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR",
+		"FREQ=MONTHLY;BYDAY=-1FR,1MO;COUNT=3":        "FREQ=MONTHLY;BYDAY=1MO,-1FR;COUNT=3",
+		"FREQ=WEEKLY;INTERVAL=1;UNTIL=20250331":      "FREQ=WEEKLY;UNTIL=20250331T235959Z",
+		"FREQ=DAILY;UNTIL=20250331T090000Z;BYDAY=MO": "FREQ=DAILY;BYDAY=MO;UNTIL=20250331T090000Z",
+	}
+
+	for input, want := range tests {
+		rule, err := rrule.Parse(input)
+		if assert.NoError(t, err, input) {
+			assert.Equal(t, want, rule.String(), input)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"FREQ=YEARLY",
+		"INTERVAL=2",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=-1",
+		"FREQ=DAILY;COUNT=2;UNTIL=20250301",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=MONTHLY;BYDAY=XX",
+		"FREQ=DAILY;BYHOUR=9",
+		"FREQ=DAILY;UNTIL=tomorrow",
+	} {
+		_, err := rrule.Parse(input)
+		assert.ErrorIs(t, err, rrule.ErrInvalidRule, input)
+	}
+}
+
+func TestDaily(t *testing.T) {
+	assert.Equal(t, []string{"2025-03-01 09:00", "2025-03-04 09:00", "2025-03-07 09:00"},
+		series(t, "FREQ=DAILY;INTERVAL=3", "2025-03-01 09:00", 3))
+
+	// 2025-03-07 is a Friday.
+	assert.Equal(t, []string{"2025-03-07 09:00", "2025-03-08 09:00", "2025-03-09 09:00", "2025-03-15 09:00"},
+		series(t, "FREQ=DAILY;BYDAY=SA,SU", "2025-03-07 09:00", 4))
+}
+
+func TestWeekly(t *testing.T) {
+	// Starts on a Wednesday, the Monday of the first week is skipped.
+	assert.Equal(t, []string{"2025-03-05 10:00", "2025-03-07 10:00", "2025-03-17 10:00", "2025-03-21 10:00"},
+		series(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", "2025-03-05 10:00", 4))
+
+	assert.Equal(t, []string{"2025-03-05 10:00", "2025-03-12 10:00"},
+		series(t, "FREQ=WEEKLY", "2025-03-05 10:00", 2))
+}
+
+func TestMonthly(t *testing.T) {
+	// Months without a 31st are skipped.
+	assert.Equal(t, []string{"2025-01-31 12:00", "2025-03-31 12:00", "2025-05-31 12:00"},
+		series(t, "FREQ=MONTHLY", "2025-01-31 12:00", 3))
+
+	assert.Equal(t, []string{"2025-01-31 12:00", "2025-02-28 12:00", "2025-03-28 12:00"},
+		series(t, "FREQ=MONTHLY;BYDAY=-1FR", "2025-01-31 12:00", 3))
+
+	assert.Equal(t, []string{"2025-03-01 08:00", "2025-03-03 08:00", "2025-03-28 08:00", "2025-04-07 08:00"},
+		series(t, "FREQ=MONTHLY;BYDAY=1MO,-1FR", "2025-03-01 08:00", 4))
+}
+
+func TestCountAndUntil(t *testing.T) {
+	// The start counts towards COUNT.
+	assert.Equal(t, []string{"2025-03-01 09:00", "2025-03-02 09:00", "2025-03-03 09:00"},
+		series(t, "FREQ=DAILY;COUNT=3", "2025-03-01 09:00", 10))
+
+	assert.Equal(t, []string{"2025-03-01 09:00", "2025-03-08 09:00", "2025-03-15 09:00"},
+		series(t, "FREQ=WEEKLY;UNTIL=20250315", "2025-03-01 09:00", 10))
+
+	rule, err := rrule.Parse("FREQ=DAILY;COUNT=2")
+	require.NoError(t, err)
+	_, ok := rule.After(date("2025-03-01 09:00"), date("2025-03-02 09:00"))
+	assert.False(t, ok)
+}
+
+func TestNoMoreOccurrences(t *testing.T) {
+	// Every seventh day after a Saturday is a Saturday, the search gives up.
+	rule, err := rrule.Parse("FREQ=DAILY;INTERVAL=7;BYDAY=MO")
+	require.NoError(t, err)
+
+	_, ok := rule.After(date("2025-03-01 09:00"), date("2025-03-01 09:00"))
+	assert.False(t, ok)
+}